@@ -0,0 +1,70 @@
+// Package metrics provides the Prometheus metrics of the llm-d inference scheduler plugins.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	giemetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	metricsutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/metrics"
+)
+
+var (
+	// ActiveRequestPodRequests is the in-flight load tracked by the ActiveRequest scorer per pod.
+	ActiveRequestPodRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: giemetrics.InferenceExtension,
+			Name:      "active_request_scorer_pod_requests",
+			Help:      metricsutil.HelpMsgWithStability("In-flight load tracked by the active request scorer per pod, in requests or in summed prompt length when weighting by prompt length.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_name", "pod"},
+	)
+
+	// ActiveRequestEvictions is the number of requests evicted by the ActiveRequest scorer, e.g., after timing
+	// out or when exceeding its capacity, by eviction reason.
+	ActiveRequestEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: giemetrics.InferenceExtension,
+			Name:      "active_request_scorer_evictions_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of in-flight requests evicted by the active request scorer, by eviction reason.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_name", "reason"},
+	)
+
+	// PDDecisions is the number of PD decisions taken by the PdProfileHandler, by decision: running prefill
+	// before decode, or decode only.
+	PDDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: giemetrics.InferenceExtension,
+			Name:      "pd_profile_handler_decisions_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of PD decisions taken by the PD profile handler, by decision.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_name", "decision"},
+	)
+
+	// PDNonCachedSuffixLength is the distribution of the non-cached prompt suffix lengths compared by the
+	// PdProfileHandler against its threshold, in the threshold unit.
+	PDNonCachedSuffixLength = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: giemetrics.InferenceExtension,
+			Name:      "pd_profile_handler_non_cached_suffix_length",
+			Help:      metricsutil.HelpMsgWithStability("Distribution of the non-cached prompt suffix lengths compared by the PD profile handler against its threshold, in the threshold unit.", compbasemetrics.ALPHA),
+			Buckets:   prometheus.ExponentialBuckets(16, 2, 13),
+		},
+		[]string{"plugin_name"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers all metrics. It is safe to call Register multiple times.
+func Register() {
+	registerMetrics.Do(func() {
+		metrics.Registry.MustRegister(ActiveRequestPodRequests)
+		metrics.Registry.MustRegister(ActiveRequestEvictions)
+		metrics.Registry.MustRegister(PDDecisions)
+		metrics.Registry.MustRegister(PDNonCachedSuffixLength)
+	})
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
@@ -19,6 +22,8 @@ type byLabelParameters struct {
 	Label         string   `json:"label"`
 	ValidValues   []string `json:"validValues"`
 	AllowsNoLabel bool     `json:"allowsNoLabel"`
+	Exclude       bool     `json:"exclude"`
+	MatchRegex    bool     `json:"matchRegex"`
 }
 
 var _ framework.Filter = &ByLabel{} // validate interface conformance
@@ -27,11 +32,18 @@ var _ framework.Filter = &ByLabel{} // validate interface conformance
 func ByLabelFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
 	parameters := byLabelParameters{}
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", ByLabelType, err)
 		}
 	}
-	return NewByLabel(name, parameters.Label, parameters.AllowsNoLabel, parameters.ValidValues...), nil
+	filter := NewByLabel(name, parameters.Label, parameters.AllowsNoLabel, parameters.ValidValues...).WithExclude(parameters.Exclude)
+	if parameters.MatchRegex {
+		var err error
+		if filter, err = filter.WithMatchRegex(); err != nil {
+			return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", ByLabelType, err)
+		}
+	}
+	return filter, nil
 }
 
 // NewByLabel creates and returns an instance of the RoleBasedFilter based on the input parameters
@@ -62,8 +74,12 @@ type ByLabel struct {
 	labelName string
 	// validValues defines list of valid label values
 	validValues map[string]struct{}
+	// validPatterns defines list of valid label value patterns, replacing validValues when set
+	validPatterns []*regexp.Regexp
 	// allowsNoLabel - if true pods without given label will be considered as valid (not filtered out)
 	allowsNoLabel bool
+	// exclude - if true the matching is inverted, pods with one of the values are filtered out
+	exclude bool
 }
 
 // TypedName returns the typed name of the plugin
@@ -77,16 +93,61 @@ func (f *ByLabel) WithName(name string) *ByLabel {
 	return f
 }
 
+// WithExclude sets whether the matching is inverted. In exclude mode, pods whose label value is
+// one of the values are filtered out, and pods without the label are kept regardless of allowsNoLabel.
+func (f *ByLabel) WithExclude(exclude bool) *ByLabel {
+	f.exclude = exclude
+	return f
+}
+
+// WithMatchRegex sets the valid values to be matched as regular expressions, each of which has to
+// match the whole label value. Pods without the label never match a pattern.
+// An error is returned if any of the valid values is not a valid regular expression.
+func (f *ByLabel) WithMatchRegex() (*ByLabel, error) {
+	validPatterns := make([]*regexp.Regexp, 0, len(f.validValues))
+	for value := range f.validValues {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("invalid regular expression in validValues - %w", err)
+		}
+		validPatterns = append(validPatterns, regexp.MustCompile("^(?:"+value+")$"))
+	}
+	f.validPatterns = validPatterns
+	return f, nil
+}
+
+// matches returns true if the given label value is one of the valid values, or matches one of the
+// valid patterns.
+func (f *ByLabel) matches(value string, labelDefined bool) bool {
+	if f.validPatterns == nil {
+		_, valueExists := f.validValues[value]
+		return valueExists
+	}
+	if !labelDefined {
+		return false
+	}
+	for _, pattern := range f.validPatterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // Filter filters out all pods that are not marked with one of roles from the validRoles collection
-// or has no role label in case allowsNoRolesLabel is true
+// or has no role label in case allowsNoRolesLabel is true.
+// In exclude mode, it filters out all pods that are marked with one of the values instead.
 func (f *ByLabel) Filter(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
 	filteredPods := []types.Pod{}
 
 	for _, pod := range pods {
 		val, labelDefined := pod.GetPod().Labels[f.labelName]
-		_, valueExists := f.validValues[val]
+		valueExists := f.matches(val, labelDefined)
 
-		if (!labelDefined && f.allowsNoLabel) || valueExists {
+		if f.exclude {
+			if !labelDefined || !valueExists {
+				filteredPods = append(filteredPods, pod)
+			}
+		} else if (!labelDefined && f.allowsNoLabel) || valueExists {
 			filteredPods = append(filteredPods, pod)
 		}
 	}
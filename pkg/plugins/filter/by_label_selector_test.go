@@ -404,6 +404,16 @@ func createPod(nsn k8stypes.NamespacedName, ipaddr string, labels map[string]str
 	}
 }
 
+func createPodWithMetrics(name string, labels map[string]string, metrics backendmetrics.MetricsState) types.Pod {
+	return &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+			Labels:         labels,
+		},
+		MetricsState: &metrics,
+	}
+}
+
 func PrefillDecodeRolesInLWS(blf *filter.ByLabelSelector, pods []types.Pod) []types.Pod {
 	return blf.Filter(context.Background(), nil, nil, pods)
 }
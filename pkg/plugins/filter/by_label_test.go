@@ -0,0 +1,136 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestByLabelFilter(t *testing.T) {
+	maintenance := createPod(k8stypes.NamespacedName{Name: "maintenance"}, "", map[string]string{"maintenance": "true"})
+	draining := createPod(k8stypes.NamespacedName{Name: "draining"}, "", map[string]string{"maintenance": "draining"})
+	available := createPod(k8stypes.NamespacedName{Name: "available"}, "", map[string]string{"maintenance": "false"})
+	unlabeled := createPod(k8stypes.NamespacedName{Name: "unlabeled"}, "", map[string]string{"other": "true"})
+	pods := []types.Pod{maintenance, draining, available, unlabeled}
+
+	tests := []struct {
+		testName      string
+		allowsNoLabel bool
+		exclude       bool
+		values        []string
+		expectedPods  []types.Pod
+	}{
+		{
+			testName:     "include",
+			values:       []string{"true", "draining"},
+			expectedPods: []types.Pod{maintenance, draining},
+		},
+		{
+			testName:      "include allowing no label",
+			allowsNoLabel: true,
+			values:        []string{"true", "draining"},
+			expectedPods:  []types.Pod{maintenance, draining, unlabeled},
+		},
+		{
+			testName:     "exclude",
+			exclude:      true,
+			values:       []string{"true", "draining"},
+			expectedPods: []types.Pod{available, unlabeled},
+		},
+		{
+			testName:      "exclude allowing no label",
+			allowsNoLabel: true,
+			exclude:       true,
+			values:        []string{"true", "draining"},
+			expectedPods:  []types.Pod{available, unlabeled},
+		},
+		{
+			testName:     "exclude without values keeps all pods",
+			exclude:      true,
+			expectedPods: pods,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			f := filter.NewByLabel("maintenance", "maintenance", tt.allowsNoLabel, tt.values...).WithExclude(tt.exclude)
+			got := f.Filter(context.Background(), nil, nil, pods)
+			assert.Equal(t, tt.expectedPods, got)
+		})
+	}
+}
+
+func TestByLabelFilterMatchRegex(t *testing.T) {
+	a100 := createPod(k8stypes.NamespacedName{Name: "a100"}, "", map[string]string{"llm-d.ai/gpu-type": "a100-80gb"})
+	h100 := createPod(k8stypes.NamespacedName{Name: "h100"}, "", map[string]string{"llm-d.ai/gpu-type": "h100-80gb"})
+	prefixed := createPod(k8stypes.NamespacedName{Name: "prefixed"}, "", map[string]string{"llm-d.ai/gpu-type": "old-a100-40gb"})
+	unlabeled := createPod(k8stypes.NamespacedName{Name: "unlabeled"}, "", map[string]string{})
+	pods := []types.Pod{a100, h100, prefixed, unlabeled}
+
+	tests := []struct {
+		testName      string
+		allowsNoLabel bool
+		exclude       bool
+		patterns      []string
+		expectedPods  []types.Pod
+	}{
+		{
+			testName:     "pattern matches the whole value",
+			patterns:     []string{"a100-.*"},
+			expectedPods: []types.Pod{a100},
+		},
+		{
+			testName:     "any of the patterns matches",
+			patterns:     []string{"a100-.*", "h100-80gb"},
+			expectedPods: []types.Pod{a100, h100},
+		},
+		{
+			testName:     "pods without label do not match a catch-all pattern",
+			patterns:     []string{".*"},
+			expectedPods: []types.Pod{a100, h100, prefixed},
+		},
+		{
+			testName:      "pods without label are kept when allowed",
+			allowsNoLabel: true,
+			patterns:      []string{"a100-.*"},
+			expectedPods:  []types.Pod{a100, unlabeled},
+		},
+		{
+			testName:     "exclude matching pods",
+			exclude:      true,
+			patterns:     []string{".*a100-.*"},
+			expectedPods: []types.Pod{h100, unlabeled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			f, err := filter.NewByLabel("gpu", "llm-d.ai/gpu-type", tt.allowsNoLabel, tt.patterns...).WithExclude(tt.exclude).WithMatchRegex()
+			require.NoError(t, err)
+			got := f.Filter(context.Background(), nil, nil, pods)
+			assert.Equal(t, tt.expectedPods, got)
+		})
+	}
+}
+
+func TestByLabelFactoryMatchRegex(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+
+	_, err := filter.ByLabelFactory("gpu", json.RawMessage(`{"label": "llm-d.ai/gpu-type", "validValues": ["a100-.*"], "matchRegex": true}`), handle)
+	assert.NoError(t, err)
+
+	_, err = filter.ByLabelFactory("gpu", json.RawMessage(`{"label": "llm-d.ai/gpu-type", "validValues": ["a100-(.*"], "matchRegex": true}`), handle)
+	assert.Error(t, err)
+
+	// invalid patterns are valid values when not matched as regular expressions
+	_, err = filter.ByLabelFactory("gpu", json.RawMessage(`{"label": "llm-d.ai/gpu-type", "validValues": ["a100-(.*"]}`), handle)
+	assert.NoError(t, err)
+}
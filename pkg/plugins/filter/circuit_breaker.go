@@ -0,0 +1,193 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// CircuitBreakerType is the type of the CircuitBreaker filter
+	CircuitBreakerType = "circuit-breaker-filter"
+
+	defaultCircuitBreakerFailureThreshold = 5
+	defaultCircuitBreakerWindow           = time.Minute
+	defaultCircuitBreakerCooldown         = 30 * time.Second
+)
+
+type circuitBreakerParameters struct {
+	// FailureThreshold is the number of failed responses within the window at which a pod is excluded
+	FailureThreshold int `json:"failureThreshold"`
+	// Window is the duration in which failures are counted, starting at the first failure.
+	// This field accepts duration strings like "30s", "1m".
+	Window string `json:"window"`
+	// Cooldown is the duration for which a pod is excluded once the threshold is reached.
+	// This field accepts duration strings like "30s", "1m".
+	Cooldown string `json:"cooldown"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Filter            = &CircuitBreaker{}
+	_ requestcontrol.PostResponse = &CircuitBreaker{}
+)
+
+// CircuitBreakerFactory defines the factory function for the CircuitBreaker filter.
+func CircuitBreakerFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := circuitBreakerParameters{FailureThreshold: defaultCircuitBreakerFailureThreshold}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", CircuitBreakerType, err)
+		}
+	}
+
+	window := parseDurationParameter(handle.Context(), "window", parameters.Window, defaultCircuitBreakerWindow)
+	cooldown := parseDurationParameter(handle.Context(), "cooldown", parameters.Cooldown, defaultCircuitBreakerCooldown)
+
+	filter, err := NewCircuitBreaker(name, parameters.FailureThreshold, window, cooldown)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", CircuitBreakerType, err)
+	}
+	return filter, nil
+}
+
+// parseDurationParameter parses the given duration parameter, falling back to the default
+// value if it is not set or invalid.
+func parseDurationParameter(ctx context.Context, parameter string, value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.FromContext(ctx).Error(err, "Invalid duration, using the default", "parameter", parameter,
+			"value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// NewCircuitBreaker creates and returns an instance of the CircuitBreaker filter
+// name - the filter name
+// failureThreshold - the number of failed responses within the window at which a pod is excluded
+// window - the duration in which failures are counted, starting at the first failure
+// cooldown - the duration for which a pod is excluded once the threshold is reached
+func NewCircuitBreaker(name string, failureThreshold int, window time.Duration, cooldown time.Duration) (*CircuitBreaker, error) {
+	if failureThreshold < 1 {
+		return nil, errors.New("failureThreshold must be positive")
+	}
+	if window <= 0 || cooldown <= 0 {
+		return nil, errors.New("window and cooldown must be positive")
+	}
+
+	return &CircuitBreaker{
+		typedName:        plugins.TypedName{Type: CircuitBreakerType, Name: name},
+		failureThreshold: failureThreshold,
+		failures: ttlcache.New[string, int](
+			ttlcache.WithTTL[string, int](window),
+			ttlcache.WithDisableTouchOnHit[string, int](),
+		),
+		open: ttlcache.New[string, struct{}](
+			ttlcache.WithTTL[string, struct{}](cooldown),
+			ttlcache.WithDisableTouchOnHit[string, struct{}](),
+		),
+	}, nil
+}
+
+// CircuitBreaker - filters out pods that recently returned failed responses. Failed (5xx) responses
+// are counted per pod within a window starting at the first failure. Once the count reaches the
+// threshold, the pod's circuit opens and the pod is filtered out until the cooldown elapses, after
+// which the pod is considered again with a clean count. If the circuits of all the pods are open,
+// no pod is filtered out.
+type CircuitBreaker struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// failureThreshold defines the number of failures within the window at which a pod is excluded
+	failureThreshold int
+
+	// failures maps the pods to their number of failures in the current window
+	failures *ttlcache.Cache[string, int]
+	// open holds the pods whose circuit is open, until their cooldown elapses
+	open  *ttlcache.Cache[string, struct{}]
+	mutex sync.Mutex
+}
+
+// TypedName returns the typed name of the plugin
+func (f *CircuitBreaker) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *CircuitBreaker) WithName(name string) *CircuitBreaker {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter filters out the pods whose circuit is open
+func (f *CircuitBreaker) Filter(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
+	filteredPods := []types.Pod{}
+	for _, pod := range pods {
+		if !f.open.Has(pod.GetPod().NamespacedName.String()) {
+			filteredPods = append(filteredPods, pod)
+		}
+	}
+
+	if len(filteredPods) == 0 && len(pods) > 0 {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Circuits of all pods are open, keeping all pods", "pods", len(pods))
+		return pods
+	}
+	return filteredPods
+}
+
+// PostResponse counts a failure of the pod that served the request if the response status is 5xx,
+// and opens the pod's circuit once the failure threshold is reached
+func (f *CircuitBreaker) PostResponse(ctx context.Context, _ *types.LLMRequest, response *requestcontrol.Response, targetPod *backend.Pod) {
+	if targetPod == nil || response == nil || !failedResponse(response) {
+		return
+	}
+	podName := targetPod.NamespacedName.String()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	failures := 1
+	ttl := ttlcache.DefaultTTL
+	if item := f.failures.Get(podName); item != nil {
+		failures = item.Value() + 1
+		ttl = time.Until(item.ExpiresAt()) // keep the window of the first failure
+	}
+
+	if failures < f.failureThreshold {
+		f.failures.Set(podName, failures, ttl)
+		return
+	}
+
+	f.failures.Delete(podName)
+	f.open.Set(podName, struct{}{}, ttlcache.DefaultTTL)
+	log.FromContext(ctx).V(logutil.DEFAULT).Info("Pod reached the failure threshold, opening its circuit",
+		"pod", podName, "failures", failures)
+}
+
+// failedResponse returns true if the status of the given response is a server error
+func failedResponse(response *requestcontrol.Response) bool {
+	status, found := response.Headers[":status"]
+	if !found {
+		status = response.Headers["status"]
+	}
+	code, err := strconv.Atoi(status)
+	return err == nil && code >= 500
+}
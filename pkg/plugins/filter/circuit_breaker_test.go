@@ -0,0 +1,103 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestCircuitBreakerFilter(t *testing.T) {
+	ctx := context.Background()
+
+	healthy := &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "healthy"}}}
+	failing := &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "failing"}}}
+	pods := []types.Pod{healthy, failing}
+
+	respond := func(cb *filter.CircuitBreaker, pod *types.PodMetrics, status string) {
+		cb.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": status}}, pod.GetPod())
+	}
+	podNames := func(pods []types.Pod) []string {
+		names := []string{}
+		for _, pod := range pods {
+			names = append(names, pod.GetPod().NamespacedName.Name)
+		}
+		return names
+	}
+
+	t.Run("trips at the threshold and recovers after the cooldown", func(t *testing.T) {
+		cb, err := filter.NewCircuitBreaker("cb", 3, time.Minute, 200*time.Millisecond)
+		require.NoError(t, err)
+
+		for range 2 {
+			respond(cb, failing, "503")
+		}
+		respond(cb, failing, "200")
+		respond(cb, failing, "404")
+		assert.Equal(t, []string{"healthy", "failing"}, podNames(cb.Filter(ctx, nil, nil, pods)), "below the threshold")
+
+		respond(cb, failing, "500")
+		assert.Equal(t, []string{"healthy"}, podNames(cb.Filter(ctx, nil, nil, pods)), "circuit should be open")
+
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, []string{"healthy", "failing"}, podNames(cb.Filter(ctx, nil, nil, pods)), "circuit should be closed")
+
+		// the failures are counted again from scratch
+		respond(cb, failing, "500")
+		assert.Equal(t, []string{"healthy", "failing"}, podNames(cb.Filter(ctx, nil, nil, pods)))
+	})
+
+	t.Run("failures outside the window are not counted", func(t *testing.T) {
+		cb, err := filter.NewCircuitBreaker("cb", 2, 200*time.Millisecond, time.Minute)
+		require.NoError(t, err)
+
+		respond(cb, failing, "500")
+		time.Sleep(300 * time.Millisecond)
+		respond(cb, failing, "500")
+		assert.Equal(t, []string{"healthy", "failing"}, podNames(cb.Filter(ctx, nil, nil, pods)))
+
+		respond(cb, failing, "502")
+		assert.Equal(t, []string{"healthy"}, podNames(cb.Filter(ctx, nil, nil, pods)))
+	})
+
+	t.Run("keeps all pods when all circuits are open", func(t *testing.T) {
+		cb, err := filter.NewCircuitBreaker("cb", 1, time.Minute, time.Minute)
+		require.NoError(t, err)
+
+		respond(cb, failing, "500")
+		respond(cb, healthy, "500")
+		assert.Equal(t, []string{"healthy", "failing"}, podNames(cb.Filter(ctx, nil, nil, pods)))
+	})
+
+	t.Run("ignores responses without a target pod", func(t *testing.T) {
+		cb, err := filter.NewCircuitBreaker("cb", 1, time.Minute, time.Minute)
+		require.NoError(t, err)
+
+		cb.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": "500"}}, nil)
+		assert.Len(t, cb.Filter(ctx, nil, nil, pods), 2)
+	})
+}
+
+func TestCircuitBreakerFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+
+	plugin, err := filter.CircuitBreakerFactory("cb", json.RawMessage(`{"failureThreshold": 2, "window": "10s", "cooldown": "invalid"}`), handle)
+	require.NoError(t, err)
+	assert.Equal(t, plugins.TypedName{Type: filter.CircuitBreakerType, Name: "cb"}, plugin.TypedName())
+
+	_, err = filter.CircuitBreakerFactory("cb", json.RawMessage(`{"failureThreshold": 0}`), handle)
+	assert.Error(t, err)
+
+	_, err = filter.CircuitBreakerFactory("cb", json.RawMessage(`{"window": 10}`), handle)
+	assert.Error(t, err)
+}
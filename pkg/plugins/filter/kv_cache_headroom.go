@@ -0,0 +1,119 @@
+package filter
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// KVCacheHeadroomType is the type of the KVCacheHeadroom filter
+	KVCacheHeadroomType = "kv-cache-headroom-filter"
+
+	defaultMaxKVCacheUtilization = 0.9
+)
+
+type kvCacheHeadroomParameters struct {
+	// MaxUtilization is the KV-cache usage (0-1) above which pods are filtered out
+	MaxUtilization float64 `json:"maxUtilization"`
+	// KeepMinPods is the number of least loaded pods kept even if their KV-cache usage is above MaxUtilization
+	KeepMinPods int `json:"keepMinPods"`
+}
+
+var _ framework.Filter = &KVCacheHeadroom{} // validate interface conformance
+
+// KVCacheHeadroomFactory defines the factory function for the KVCacheHeadroom filter.
+func KVCacheHeadroomFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := kvCacheHeadroomParameters{MaxUtilization: defaultMaxKVCacheUtilization}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", KVCacheHeadroomType, err)
+		}
+	}
+
+	filter, err := NewKVCacheHeadroom(name, parameters.MaxUtilization, parameters.KeepMinPods)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", KVCacheHeadroomType, err)
+	}
+	return filter, nil
+}
+
+// NewKVCacheHeadroom creates and returns an instance of the KVCacheHeadroom filter
+// name - the filter name
+// maxUtilization - the KV-cache usage (0-1) above which pods are filtered out
+// keepMinPods - the number of least loaded pods kept even if their KV-cache usage is above maxUtilization
+func NewKVCacheHeadroom(name string, maxUtilization float64, keepMinPods int) (*KVCacheHeadroom, error) {
+	if maxUtilization <= 0 || maxUtilization > 1 {
+		return nil, errors.New("maxUtilization must be in range (0, 1]")
+	}
+	if keepMinPods < 0 {
+		return nil, errors.New("keepMinPods must not be negative")
+	}
+
+	return &KVCacheHeadroom{
+		typedName:      plugins.TypedName{Type: KVCacheHeadroomType, Name: name},
+		maxUtilization: maxUtilization,
+		keepMinPods:    keepMinPods,
+	}, nil
+}
+
+// KVCacheHeadroom - filters out pods whose KV-cache usage is above a ceiling, so heavily loaded pods
+// are not considered while healthier ones exist. To avoid filtering out all pods during a cluster-wide
+// spike, a minimal number of the least loaded pods can be kept regardless of the ceiling.
+type KVCacheHeadroom struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// maxUtilization defines the KV-cache usage above which pods are filtered out
+	maxUtilization float64
+	// keepMinPods defines the number of least loaded pods kept regardless of maxUtilization
+	keepMinPods int
+}
+
+// TypedName returns the typed name of the plugin
+func (f *KVCacheHeadroom) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *KVCacheHeadroom) WithName(name string) *KVCacheHeadroom {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter filters out all pods whose KV-cache usage is above the ceiling. If fewer than keepMinPods
+// pods remain, the least loaded of the filtered out pods are kept as well, up to keepMinPods pods.
+func (f *KVCacheHeadroom) Filter(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
+	filteredPods := []types.Pod{}
+	overloadedPods := []types.Pod{}
+
+	for _, pod := range pods {
+		if pod.GetMetrics().KVCacheUsagePercent <= f.maxUtilization {
+			filteredPods = append(filteredPods, pod)
+		} else {
+			overloadedPods = append(overloadedPods, pod)
+		}
+	}
+
+	if missing := f.keepMinPods - len(filteredPods); missing > 0 && len(overloadedPods) > 0 {
+		slices.SortStableFunc(overloadedPods, func(a, b types.Pod) int {
+			return cmp.Compare(a.GetMetrics().KVCacheUsagePercent, b.GetMetrics().KVCacheUsagePercent)
+		})
+		kept := overloadedPods[:min(missing, len(overloadedPods))]
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Keeping pods above the KV-cache usage ceiling",
+			"ceiling", f.maxUtilization, "kept", len(kept))
+		filteredPods = append(filteredPods, kept...)
+	}
+
+	return filteredPods
+}
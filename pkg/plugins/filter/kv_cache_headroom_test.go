@@ -0,0 +1,83 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestKVCacheHeadroomFilter(t *testing.T) {
+	idle := createPodWithMetrics("idle", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 0.1})
+	busy := createPodWithMetrics("busy", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 0.8})
+	atCeiling := createPodWithMetrics("at-ceiling", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 0.9})
+	overloaded := createPodWithMetrics("overloaded", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 0.95})
+	full := createPodWithMetrics("full", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 1.0})
+
+	tests := []struct {
+		testName     string
+		keepMinPods  int
+		pods         []types.Pod
+		expectedPods []types.Pod
+	}{
+		{
+			testName:     "pods above the ceiling are filtered out",
+			pods:         []types.Pod{full, idle, overloaded, busy, atCeiling},
+			expectedPods: []types.Pod{idle, busy, atCeiling},
+		},
+		{
+			testName:     "all pods above the ceiling are filtered out without keeping pods",
+			pods:         []types.Pod{full, overloaded},
+			expectedPods: []types.Pod{},
+		},
+		{
+			testName:     "least loaded pods are kept when all are above the ceiling",
+			keepMinPods:  1,
+			pods:         []types.Pod{full, overloaded},
+			expectedPods: []types.Pod{overloaded},
+		},
+		{
+			testName:     "least loaded pods complete the pods below the ceiling",
+			keepMinPods:  2,
+			pods:         []types.Pod{full, overloaded, idle},
+			expectedPods: []types.Pod{idle, overloaded},
+		},
+		{
+			testName:     "no pods are added when enough are below the ceiling",
+			keepMinPods:  2,
+			pods:         []types.Pod{full, idle, busy},
+			expectedPods: []types.Pod{idle, busy},
+		},
+		{
+			testName:     "all pods are kept when fewer than keepMinPods",
+			keepMinPods:  5,
+			pods:         []types.Pod{full, overloaded},
+			expectedPods: []types.Pod{overloaded, full},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			f, err := filter.NewKVCacheHeadroom("kv-cache-headroom", 0.9, tt.keepMinPods)
+			require.NoError(t, err)
+			got := f.Filter(context.Background(), nil, nil, tt.pods)
+			assert.Equal(t, tt.expectedPods, got)
+		})
+	}
+}
+
+func TestKVCacheHeadroomFactory(t *testing.T) {
+	_, err := filter.KVCacheHeadroomFactory("kv-cache-headroom", json.RawMessage(`{"maxUtilization": 0.8, "keepMinPods": 2}`), nil)
+	assert.NoError(t, err)
+
+	for _, params := range []string{`{"maxUtilization": 0}`, `{"maxUtilization": 1.5}`, `{"keepMinPods": -1}`} {
+		_, err := filter.KVCacheHeadroomFactory("kv-cache-headroom", json.RawMessage(params), nil)
+		assert.Error(t, err, params)
+	}
+}
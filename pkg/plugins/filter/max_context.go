@@ -0,0 +1,114 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// MaxContextType is the type of the MaxContext filter
+	MaxContextType = "max-context-filter"
+
+	// MaxContextLabelDefault is the default pod label holding the maximal context length (in tokens) of a pod
+	MaxContextLabelDefault = "llm-d.ai/max-model-len"
+
+	// charsPerTokenDefault is the default number of prompt characters estimated per token
+	charsPerTokenDefault = 4
+)
+
+type maxContextParameters struct {
+	Label         string `json:"label"`
+	CharsPerToken int    `json:"charsPerToken"`
+}
+
+var _ framework.Filter = &MaxContext{} // validate interface conformance
+
+// MaxContextFactory defines the factory function for the MaxContext filter.
+func MaxContextFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := maxContextParameters{Label: MaxContextLabelDefault, CharsPerToken: charsPerTokenDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", MaxContextType, err)
+		}
+	}
+	return NewMaxContext(name, parameters.Label, parameters.CharsPerToken), nil
+}
+
+// NewMaxContext creates and returns an instance of the MaxContext filter
+// name - the filter name
+// labelName - the name of the label holding the maximal context length of a pod in tokens
+// charsPerToken - the number of prompt characters estimated per token
+func NewMaxContext(name string, labelName string, charsPerToken int) *MaxContext {
+	if charsPerToken <= 0 {
+		charsPerToken = charsPerTokenDefault
+	}
+
+	return &MaxContext{
+		typedName:     plugins.TypedName{Type: MaxContextType, Name: name},
+		labelName:     labelName,
+		charsPerToken: charsPerToken,
+	}
+}
+
+// MaxContext - filters out pods whose maximal context length, as defined by the given label,
+// can't accommodate the request's prompt. Pods without the label are considered unbounded.
+type MaxContext struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// labelName defines the name of the label holding the maximal context length
+	labelName string
+	// charsPerToken defines the number of prompt characters estimated per token
+	charsPerToken int
+}
+
+// TypedName returns the typed name of the plugin
+func (f *MaxContext) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *MaxContext) WithName(name string) *MaxContext {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter filters out all pods whose maximal context length is smaller than the estimated
+// number of tokens in the request's prompt
+func (f *MaxContext) Filter(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	if request == nil {
+		return pods
+	}
+	promptTokens := (len(request.Prompt) + f.charsPerToken - 1) / f.charsPerToken
+
+	filteredPods := []types.Pod{}
+	for _, pod := range pods {
+		val, labelDefined := pod.GetPod().Labels[f.labelName]
+		if !labelDefined {
+			filteredPods = append(filteredPods, pod)
+			continue
+		}
+
+		maxContext, err := strconv.Atoi(val)
+		if err != nil {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Ignoring invalid max context label", "pod", pod.GetPod().NamespacedName, "value", val)
+			filteredPods = append(filteredPods, pod)
+			continue
+		}
+
+		if promptTokens <= maxContext {
+			filteredPods = append(filteredPods, pod)
+		}
+	}
+
+	return filteredPods
+}
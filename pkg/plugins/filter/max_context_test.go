@@ -0,0 +1,86 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestMaxContextFilter(t *testing.T) {
+	pods := []types.Pod{
+		createPod(k8stypes.NamespacedName{Namespace: "default", Name: "small"}, "10.0.0.1",
+			map[string]string{filter.MaxContextLabelDefault: "4096"}),
+		createPod(k8stypes.NamespacedName{Namespace: "default", Name: "large"}, "10.0.0.2",
+			map[string]string{filter.MaxContextLabelDefault: "131072"}),
+		createPod(k8stypes.NamespacedName{Namespace: "default", Name: "unlabeled"}, "10.0.0.3", nil),
+		createPod(k8stypes.NamespacedName{Namespace: "default", Name: "invalid"}, "10.0.0.4",
+			map[string]string{filter.MaxContextLabelDefault: "many"}),
+	}
+
+	tests := []struct {
+		testName     string
+		prompt       string
+		expectedPods []string
+	}{
+		{
+			testName:     "short request fits all pods",
+			prompt:       strings.Repeat("a", 1000),
+			expectedPods: []string{"small", "large", "unlabeled", "invalid"},
+		},
+		{
+			testName:     "long-context request excludes small pods",
+			prompt:       strings.Repeat("a", 4*8192),
+			expectedPods: []string{"large", "unlabeled", "invalid"},
+		},
+		{
+			testName:     "request exactly at the limit is accepted",
+			prompt:       strings.Repeat("a", 4*4096),
+			expectedPods: []string{"small", "large", "unlabeled", "invalid"},
+		},
+	}
+
+	plugin, err := filter.MaxContextFactory("max-context", nil, nil)
+	require.NoError(t, err)
+	mcf, ok := plugin.(*filter.MaxContext)
+	require.True(t, ok, "plugin should be of type *MaxContext")
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			filteredPods := mcf.Filter(context.Background(), nil, &types.LLMRequest{Prompt: tt.prompt}, pods)
+
+			var actualPodNames []string
+			for _, pod := range filteredPods {
+				actualPodNames = append(actualPodNames, pod.GetPod().NamespacedName.Name)
+			}
+
+			assert.ElementsMatch(t, tt.expectedPods, actualPodNames,
+				"filtered pods should match expected pods")
+		})
+	}
+}
+
+func TestMaxContextFactoryWithJSON(t *testing.T) {
+	rawParams := json.RawMessage(`{"label": "max-len", "charsPerToken": 2}`)
+	plugin, err := filter.MaxContextFactory("max-context", rawParams, nil)
+	require.NoError(t, err)
+
+	mcf, ok := plugin.(*filter.MaxContext)
+	require.True(t, ok, "plugin should be of type *MaxContext")
+
+	pods := []types.Pod{
+		createPod(k8stypes.NamespacedName{Name: "pod-1"}, "10.0.0.1", map[string]string{"max-len": "100"}),
+	}
+	result := mcf.Filter(context.Background(), nil, &types.LLMRequest{Prompt: strings.Repeat("a", 202)}, pods)
+	assert.Empty(t, result, "202 characters are estimated as 101 tokens")
+
+	_, err = filter.MaxContextFactory("max-context", json.RawMessage(`{"label": 5}`), nil)
+	assert.Error(t, err)
+}
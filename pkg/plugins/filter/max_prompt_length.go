@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/rejection"
+)
+
+const (
+	// MaxPromptLengthType is the type of the MaxPromptLength filter
+	MaxPromptLengthType = "max-prompt-length-filter"
+
+	// PromptLengthUnitBytes measures the prompt length in bytes
+	PromptLengthUnitBytes = "bytes"
+	// PromptLengthUnitTokens measures the prompt length in estimated tokens
+	PromptLengthUnitTokens = "tokens"
+)
+
+type maxPromptLengthParameters struct {
+	MaxLength     int    `json:"maxLength"`
+	Unit          string `json:"unit"`
+	CharsPerToken int    `json:"charsPerToken"`
+}
+
+var _ framework.Filter = &MaxPromptLength{} // validate interface conformance
+
+// MaxPromptLengthFactory defines the factory function for the MaxPromptLength filter.
+func MaxPromptLengthFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := maxPromptLengthParameters{Unit: PromptLengthUnitBytes, CharsPerToken: charsPerTokenDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", MaxPromptLengthType, err)
+		}
+	}
+
+	filter, err := NewMaxPromptLength(name, parameters.MaxLength, parameters.Unit, parameters.CharsPerToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", MaxPromptLengthType, err)
+	}
+	return filter, nil
+}
+
+// NewMaxPromptLength creates and returns an instance of the MaxPromptLength filter
+// name - the filter name
+// maxLength - the maximal prompt length, in the given unit
+// unit - the unit the prompt length is measured in, PromptLengthUnitBytes or PromptLengthUnitTokens
+// charsPerToken - the number of prompt characters estimated per token, when measuring in tokens
+func NewMaxPromptLength(name string, maxLength int, unit string, charsPerToken int) (*MaxPromptLength, error) {
+	if maxLength <= 0 {
+		return nil, errors.New("maxLength must be positive")
+	}
+	if unit != PromptLengthUnitBytes && unit != PromptLengthUnitTokens {
+		return nil, fmt.Errorf("unknown unit '%s', expected one of '%s' or '%s'", unit, PromptLengthUnitBytes, PromptLengthUnitTokens)
+	}
+	if charsPerToken <= 0 {
+		charsPerToken = charsPerTokenDefault
+	}
+
+	return &MaxPromptLength{
+		typedName:     plugins.TypedName{Type: MaxPromptLengthType, Name: name},
+		maxLength:     maxLength,
+		unit:          unit,
+		charsPerToken: charsPerToken,
+	}, nil
+}
+
+// MaxPromptLength - rejects requests whose prompt is longer than a ceiling, so extremely long prompts
+// can't monopolize the prefill capacity of the fleet. A rejected request is filtered out of all pods,
+// failing its scheduling, and a rejection.PromptTooLongError is recorded in the cycle state so the
+// profile handler can surface the reason. Note that the EPP reports every scheduling failure as a
+// resource exhausted error, so the client receives a 429 rather than a 413 for a rejected request.
+type MaxPromptLength struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// maxLength defines the maximal prompt length
+	maxLength int
+	// unit defines the unit the prompt length is measured in
+	unit string
+	// charsPerToken defines the number of prompt characters estimated per token
+	charsPerToken int
+}
+
+// TypedName returns the typed name of the plugin
+func (f *MaxPromptLength) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *MaxPromptLength) WithName(name string) *MaxPromptLength {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter filters out all pods if the request's prompt is longer than the ceiling, recording the
+// reason in the cycle state. Otherwise, all pods are kept.
+func (f *MaxPromptLength) Filter(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	if request == nil {
+		return pods
+	}
+
+	length := len(request.Prompt)
+	if f.unit == PromptLengthUnitTokens {
+		length = (length + f.charsPerToken - 1) / f.charsPerToken
+	}
+	if length <= f.maxLength {
+		return pods
+	}
+
+	reason := &rejection.PromptTooLongError{Length: length, MaxLength: f.maxLength, Unit: f.unit}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Rejecting request", "reason", reason.Error())
+	if cycleState != nil {
+		cycleState.Write(rejection.PromptTooLongStateKey, reason)
+	}
+	return []types.Pod{}
+}
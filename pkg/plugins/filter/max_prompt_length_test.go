@@ -0,0 +1,91 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/rejection"
+)
+
+func TestMaxPromptLengthFilter(t *testing.T) {
+	pods := []types.Pod{
+		&types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-1"}}},
+		&types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-2"}}},
+	}
+
+	tests := []struct {
+		name       string
+		unit       string
+		maxLength  int
+		prompt     string
+		wantReason *rejection.PromptTooLongError
+	}{
+		{
+			name:      "bytes under the limit",
+			unit:      filter.PromptLengthUnitBytes,
+			maxLength: 100,
+			prompt:    strings.Repeat("a", 100),
+		},
+		{
+			name:       "bytes over the limit",
+			unit:       filter.PromptLengthUnitBytes,
+			maxLength:  100,
+			prompt:     strings.Repeat("a", 101),
+			wantReason: &rejection.PromptTooLongError{Length: 101, MaxLength: 100, Unit: filter.PromptLengthUnitBytes},
+		},
+		{
+			name:      "tokens under the limit",
+			unit:      filter.PromptLengthUnitTokens,
+			maxLength: 100,
+			prompt:    strings.Repeat("a", 400),
+		},
+		{
+			name:       "tokens over the limit",
+			unit:       filter.PromptLengthUnitTokens,
+			maxLength:  100,
+			prompt:     strings.Repeat("a", 401),
+			wantReason: &rejection.PromptTooLongError{Length: 101, MaxLength: 100, Unit: filter.PromptLengthUnitTokens},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := filter.NewMaxPromptLength("max-prompt-length", test.maxLength, test.unit, 4)
+			require.NoError(t, err)
+
+			cycleState := types.NewCycleState()
+			got := f.Filter(context.Background(), cycleState, &types.LLMRequest{Prompt: test.prompt}, pods)
+
+			reason, err := types.ReadCycleStateKey[*rejection.PromptTooLongError](cycleState, rejection.PromptTooLongStateKey)
+			if test.wantReason == nil {
+				assert.Equal(t, pods, got)
+				assert.Error(t, err, "no reason should be recorded")
+				return
+			}
+			assert.Empty(t, got)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantReason, reason)
+		})
+	}
+}
+
+func TestMaxPromptLengthFactory(t *testing.T) {
+	plugin, err := filter.MaxPromptLengthFactory("max-prompt-length", json.RawMessage(`{"maxLength": 1000, "unit": "tokens"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, filter.MaxPromptLengthType, plugin.TypedName().Type)
+
+	_, err = filter.MaxPromptLengthFactory("max-prompt-length", json.RawMessage(`{"maxLength": 0}`), nil)
+	assert.Error(t, err, "maxLength is required")
+
+	_, err = filter.MaxPromptLengthFactory("max-prompt-length", json.RawMessage(`{"maxLength": 1000, "unit": "words"}`), nil)
+	assert.Error(t, err)
+}
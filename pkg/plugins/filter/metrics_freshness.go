@@ -0,0 +1,123 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// MetricsFreshnessType is the type of the MetricsFreshness filter
+	MetricsFreshnessType = "metrics-freshness-filter"
+
+	defaultMaxMetricsAge           = 5 * time.Second
+	defaultMetricsFreshnessMinPods = 1
+)
+
+type metricsFreshnessParameters struct {
+	// MaxMetricsAge is the age of the metrics of a pod above which the pod is filtered out, e.g., "5s"
+	MaxMetricsAge string `json:"maxMetricsAge"`
+	// KeepMinPods is the number of freshest pods kept even if their metrics are older than MaxMetricsAge
+	KeepMinPods int `json:"keepMinPods"`
+}
+
+var _ framework.Filter = &MetricsFreshness{} // validate interface conformance
+
+// MetricsFreshnessFactory defines the factory function for the MetricsFreshness filter.
+func MetricsFreshnessFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := metricsFreshnessParameters{KeepMinPods: defaultMetricsFreshnessMinPods}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", MetricsFreshnessType, err)
+		}
+	}
+
+	maxMetricsAge := parseDurationParameter(handle.Context(), "maxMetricsAge", parameters.MaxMetricsAge, defaultMaxMetricsAge)
+	filter, err := NewMetricsFreshness(name, maxMetricsAge, parameters.KeepMinPods)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", MetricsFreshnessType, err)
+	}
+	return filter, nil
+}
+
+// NewMetricsFreshness creates and returns an instance of the MetricsFreshness filter
+// name - the filter name
+// maxMetricsAge - the age of the metrics of a pod above which the pod is filtered out
+// keepMinPods - the number of freshest pods kept even if their metrics are older than maxMetricsAge
+func NewMetricsFreshness(name string, maxMetricsAge time.Duration, keepMinPods int) (*MetricsFreshness, error) {
+	if maxMetricsAge <= 0 {
+		return nil, errors.New("maxMetricsAge must be positive")
+	}
+	if keepMinPods < 0 {
+		return nil, errors.New("keepMinPods must not be negative")
+	}
+
+	return &MetricsFreshness{
+		typedName:     plugins.TypedName{Type: MetricsFreshnessType, Name: name},
+		maxMetricsAge: maxMetricsAge,
+		keepMinPods:   keepMinPods,
+	}, nil
+}
+
+// MetricsFreshness - filters out pods whose metrics haven't been updated recently. A pod can be
+// ready while scraping its metrics fails, leaving stale metrics that make load based scorers
+// misleadingly favor it. To avoid filtering out all pods when scraping fails cluster-wide, a
+// minimal number of the freshest pods can be kept regardless of their metrics age.
+type MetricsFreshness struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// maxMetricsAge defines the age of the metrics above which pods are filtered out
+	maxMetricsAge time.Duration
+	// keepMinPods defines the number of freshest pods kept regardless of maxMetricsAge
+	keepMinPods int
+}
+
+// TypedName returns the typed name of the plugin
+func (f *MetricsFreshness) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *MetricsFreshness) WithName(name string) *MetricsFreshness {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter filters out all pods whose metrics are older than the maximal age. If fewer than
+// keepMinPods pods remain, the freshest of the filtered out pods are kept as well, up to
+// keepMinPods pods.
+func (f *MetricsFreshness) Filter(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
+	filteredPods := []types.Pod{}
+	stalePods := []types.Pod{}
+
+	for _, pod := range pods {
+		if time.Since(pod.GetMetrics().UpdateTime) <= f.maxMetricsAge {
+			filteredPods = append(filteredPods, pod)
+		} else {
+			stalePods = append(stalePods, pod)
+		}
+	}
+
+	if missing := f.keepMinPods - len(filteredPods); missing > 0 && len(stalePods) > 0 {
+		slices.SortStableFunc(stalePods, func(a, b types.Pod) int {
+			return b.GetMetrics().UpdateTime.Compare(a.GetMetrics().UpdateTime)
+		})
+		kept := stalePods[:min(missing, len(stalePods))]
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Keeping pods with stale metrics",
+			"maxMetricsAge", f.maxMetricsAge, "kept", len(kept))
+		filteredPods = append(filteredPods, kept...)
+	}
+
+	return filteredPods
+}
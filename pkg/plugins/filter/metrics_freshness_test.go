@@ -0,0 +1,92 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestMetricsFreshnessFilter(t *testing.T) {
+	now := time.Now()
+	fresh := createPodWithMetrics("fresh", nil, backendmetrics.MetricsState{UpdateTime: now})
+	recent := createPodWithMetrics("recent", nil, backendmetrics.MetricsState{UpdateTime: now.Add(-time.Second)})
+	stale := createPodWithMetrics("stale", nil, backendmetrics.MetricsState{UpdateTime: now.Add(-time.Minute)})
+	staler := createPodWithMetrics("staler", nil, backendmetrics.MetricsState{UpdateTime: now.Add(-time.Hour)})
+
+	tests := []struct {
+		testName     string
+		keepMinPods  int
+		pods         []types.Pod
+		expectedPods []types.Pod
+	}{
+		{
+			testName:     "pods with stale metrics are filtered out",
+			pods:         []types.Pod{staler, fresh, stale, recent},
+			expectedPods: []types.Pod{fresh, recent},
+		},
+		{
+			testName:     "all pods with stale metrics are filtered out without keeping pods",
+			pods:         []types.Pod{staler, stale},
+			expectedPods: []types.Pod{},
+		},
+		{
+			testName:     "freshest pods are kept when all are stale",
+			keepMinPods:  1,
+			pods:         []types.Pod{staler, stale},
+			expectedPods: []types.Pod{stale},
+		},
+		{
+			testName:     "freshest stale pods complete the fresh pods",
+			keepMinPods:  2,
+			pods:         []types.Pod{staler, stale, fresh},
+			expectedPods: []types.Pod{fresh, stale},
+		},
+		{
+			testName:     "no pods are added when enough are fresh",
+			keepMinPods:  2,
+			pods:         []types.Pod{staler, fresh, recent},
+			expectedPods: []types.Pod{fresh, recent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			f, err := filter.NewMetricsFreshness("metrics-freshness", 5*time.Second, tt.keepMinPods)
+			require.NoError(t, err)
+			got := f.Filter(context.Background(), nil, nil, tt.pods)
+			assert.Equal(t, tt.expectedPods, got)
+		})
+	}
+}
+
+func TestMetricsFreshnessFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+	stale := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "stale"}},
+		MetricsState: &backendmetrics.MetricsState{UpdateTime: time.Now().Add(-time.Minute)},
+	}
+
+	plugin, err := filter.MetricsFreshnessFactory("metrics-freshness", nil, handle)
+	require.NoError(t, err)
+	got := plugin.(*filter.MetricsFreshness).Filter(context.Background(), nil, nil, []types.Pod{stale})
+	assert.Equal(t, []types.Pod{stale}, got, "a single pod is kept by default")
+
+	plugin, err = filter.MetricsFreshnessFactory("metrics-freshness", json.RawMessage(`{"maxMetricsAge": "2m", "keepMinPods": 0}`), handle)
+	require.NoError(t, err)
+	got = plugin.(*filter.MetricsFreshness).Filter(context.Background(), nil, nil, []types.Pod{stale})
+	assert.Equal(t, []types.Pod{stale}, got)
+
+	_, err = filter.MetricsFreshnessFactory("metrics-freshness", json.RawMessage(`{"keepMinPods": -1}`), handle)
+	assert.Error(t, err)
+}
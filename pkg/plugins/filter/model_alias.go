@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// ModelAliasType is the type of the ModelAlias filter
+	ModelAliasType = "model-alias-filter"
+)
+
+type modelAliasParameters struct {
+	// Aliases maps model aliases to the concrete model names they stand for
+	Aliases map[string]string `json:"aliases"`
+}
+
+var _ framework.Filter = &ModelAlias{} // validate interface conformance
+
+// ModelAliasFactory defines the factory function for the ModelAlias filter.
+func ModelAliasFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := modelAliasParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", ModelAliasType, err)
+		}
+	}
+
+	filter, err := NewModelAlias(name, parameters.Aliases)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", ModelAliasType, err)
+	}
+	return filter, nil
+}
+
+// NewModelAlias creates and returns an instance of the ModelAlias filter
+// name - the filter name
+// aliases - maps model aliases to the concrete model names they stand for
+func NewModelAlias(name string, aliases map[string]string) (*ModelAlias, error) {
+	if len(aliases) == 0 {
+		return nil, errors.New("aliases must not be empty")
+	}
+	for alias, model := range aliases {
+		if alias == "" || model == "" {
+			return nil, fmt.Errorf("invalid alias '%s' of model '%s', both must be set", alias, model)
+		}
+	}
+
+	return &ModelAlias{
+		typedName: plugins.TypedName{Type: ModelAliasType, Name: name},
+		aliases:   maps.Clone(aliases),
+	}, nil
+}
+
+// ModelAlias - rewrites the target model of requests addressing a model alias, e.g., a friendly name
+// exposed at the gateway, to the concrete model name it stands for, so the following scorers key their
+// state, e.g., prefix cache entries, off the concrete model name. It is placed first in the filters of
+// the first scheduling profile, and filters out no pods. Only the request seen by the scheduling plugins
+// is rewritten, not the request forwarded to the model server.
+type ModelAlias struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// aliases maps model aliases to the concrete model names they stand for
+	aliases map[string]string
+}
+
+// TypedName returns the typed name of the plugin
+func (f *ModelAlias) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *ModelAlias) WithName(name string) *ModelAlias {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter rewrites the target model of the request if it is an alias, keeping all pods.
+func (f *ModelAlias) Filter(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	if request == nil {
+		return pods
+	}
+
+	if model, found := f.aliases[request.TargetModel]; found {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Rewriting model alias", "alias", request.TargetModel, "model", model)
+		request.TargetModel = model
+	}
+	return pods
+}
@@ -0,0 +1,94 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+const concreteModel = "Qwen/Qwen2.5-7B-Instruct"
+
+func TestModelAliasFilter(t *testing.T) {
+	pods := []types.Pod{
+		&types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-1"}}},
+	}
+	f, err := filter.NewModelAlias("model-alias", map[string]string{"qwen": concreteModel})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		model     string
+		wantModel string
+	}{
+		{name: "alias is rewritten", model: "qwen", wantModel: concreteModel},
+		{name: "concrete model is kept", model: concreteModel, wantModel: concreteModel},
+		{name: "unknown model is kept", model: "llama", wantModel: "llama"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := &types.LLMRequest{TargetModel: test.model}
+			got := f.Filter(context.Background(), nil, request, pods)
+			assert.Equal(t, pods, got)
+			assert.Equal(t, test.wantModel, request.TargetModel)
+		})
+	}
+}
+
+func TestModelAliasFilter_PrefixMatch(t *testing.T) {
+	ctx := context.Background()
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-1"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{pod}
+	prompt := "a prompt long enough to span several prefix blocks"
+
+	f, err := filter.NewModelAlias("model-alias", map[string]string{"qwen": concreteModel})
+	require.NoError(t, err)
+	prefixScorer := prefix.New(ctx, prefix.Config{HashBlockSize: 4, MaxPrefixBlocksToMatch: 256, LRUCapacityPerServer: 1000})
+
+	// a request addressing the concrete model is served by the pod, and stored in the prefix cache
+	request := &types.LLMRequest{RequestId: uuid.NewString(), TargetModel: concreteModel, Prompt: prompt}
+	prefixScorer.Score(ctx, types.NewCycleState(), request, pods)
+	prefixScorer.PreRequest(ctx, request, &types.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults:     map[string]*types.ProfileRunResult{"default": {TargetPods: pods}},
+	}, 8000)
+
+	score := func(model string, alias bool) float64 {
+		request := &types.LLMRequest{RequestId: uuid.NewString(), TargetModel: model, Prompt: prompt}
+		if alias {
+			f.Filter(ctx, nil, request, pods)
+		}
+		return prefixScorer.Score(ctx, types.NewCycleState(), request, pods)[pod]
+	}
+
+	// the prefix cache is populated asynchronously
+	require.Eventually(t, func() bool { return score(concreteModel, false) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0.0, score("qwen", false), "an alias doesn't match entries of the concrete model")
+	assert.Equal(t, 1.0, score("qwen", true), "a rewritten alias matches entries of the concrete model")
+}
+
+func TestModelAliasFactory(t *testing.T) {
+	plugin, err := filter.ModelAliasFactory("model-alias", json.RawMessage(`{"aliases": {"qwen": "Qwen/Qwen2.5-7B-Instruct"}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, filter.ModelAliasType, plugin.TypedName().Type)
+
+	for _, params := range []string{`{}`, `{"aliases": {}}`, `{"aliases": {"qwen": ""}}`} {
+		_, err := filter.ModelAliasFactory("model-alias", json.RawMessage(params), nil)
+		assert.Error(t, err, params)
+	}
+}
@@ -29,7 +29,7 @@ func PrefillRoleFactory(name string, _ json.RawMessage, _ plugins.Handle) (plugi
 
 // NewPrefillRole creates and returns an instance of the Filter configured for prefill role
 func NewPrefillRole() *ByLabel {
-	return NewByLabel(PrefillRoleType, RoleLabel, false, RolePrefill)
+	return NewByLabel(PrefillRoleType, RoleLabel, false, RolePrefill, RoleBoth)
 }
 
 // DecodeRoleFactory defines the factory function for the Decode filter.
@@ -0,0 +1,53 @@
+package filter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestPDRoleFilters(t *testing.T) {
+	prefillPod := createPod(k8stypes.NamespacedName{Name: "prefill"}, "10.0.0.1", map[string]string{filter.RoleLabel: filter.RolePrefill})
+	decodePod := createPod(k8stypes.NamespacedName{Name: "decode"}, "10.0.0.2", map[string]string{filter.RoleLabel: filter.RoleDecode})
+	bothPod := createPod(k8stypes.NamespacedName{Name: "both"}, "10.0.0.3", map[string]string{filter.RoleLabel: filter.RoleBoth})
+	noRolePod := createPod(k8stypes.NamespacedName{Name: "no-role"}, "10.0.0.4", nil)
+
+	tests := []struct {
+		testName        string
+		pods            []types.Pod
+		expectedPrefill []types.Pod
+		expectedDecode  []types.Pod
+	}{
+		{
+			testName:        "mixed pool",
+			pods:            []types.Pod{prefillPod, decodePod, bothPod, noRolePod},
+			expectedPrefill: []types.Pod{prefillPod, bothPod},
+			expectedDecode:  []types.Pod{decodePod, bothPod, noRolePod},
+		},
+		{
+			testName:        "dedicated roles",
+			pods:            []types.Pod{prefillPod, decodePod},
+			expectedPrefill: []types.Pod{prefillPod},
+			expectedDecode:  []types.Pod{decodePod},
+		},
+		{
+			testName:        "only both pods",
+			pods:            []types.Pod{bothPod},
+			expectedPrefill: []types.Pod{bothPod},
+			expectedDecode:  []types.Pod{bothPod},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			assert.Equal(t, tt.expectedPrefill, filter.NewPrefillRole().Filter(ctx, nil, nil, tt.pods))
+			assert.Equal(t, tt.expectedDecode, filter.NewDecodeRole().Filter(ctx, nil, nil, tt.pods))
+		})
+	}
+}
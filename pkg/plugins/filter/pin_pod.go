@@ -0,0 +1,102 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// PinPodType is the type of the PinPod filter
+	PinPodType = "pin-pod-filter"
+
+	// PinPodHeaderDefault is the default request header holding the namespace/name of the pod to pin a request to
+	PinPodHeaderDefault = "x-target-pod"
+)
+
+type pinPodParameters struct {
+	Header string `json:"header"`
+	Strict bool   `json:"strict"`
+}
+
+var _ framework.Filter = &PinPod{} // validate interface conformance
+
+// PinPodFactory defines the factory function for the PinPod filter.
+func PinPodFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := pinPodParameters{Header: PinPodHeaderDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", PinPodType, err)
+		}
+	}
+	if parameters.Header == "" {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - header must not be empty", PinPodType)
+	}
+	return NewPinPod(name, parameters.Header, parameters.Strict), nil
+}
+
+// NewPinPod creates and returns an instance of the PinPod filter
+// name - the filter name
+// header - the name of the request header holding the namespace/name of the pod to pin a request to
+// strict - if true requests pinned to a pod that is not a candidate fail, rather than being scheduled normally
+func NewPinPod(name string, header string, strict bool) *PinPod {
+	return &PinPod{
+		typedName: plugins.TypedName{Type: PinPodType, Name: name},
+		header:    header,
+		strict:    strict,
+	}
+}
+
+// PinPod - pins requests to the pod named, as namespace/name, by a request header, e.g. for A/B
+// testing and debugging, by keeping only that pod. Requests pinned to a pod that is not a candidate
+// either fail, when strict, or are not filtered. Requests without the header are not filtered.
+// The header should only be accepted from trusted clients, e.g. by removing it at the gateway.
+type PinPod struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// header defines the name of the request header holding the namespace/name of the pod to pin a request to
+	header string
+	// strict - if true requests pinned to a pod that is not a candidate fail
+	strict bool
+}
+
+// TypedName returns the typed name of the plugin
+func (f *PinPod) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *PinPod) WithName(name string) *PinPod {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter keeps only the pod the request is pinned to, if any.
+func (f *PinPod) Filter(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	if request == nil || request.Headers[f.header] == "" {
+		return pods
+	}
+	target := request.Headers[f.header]
+
+	for _, pod := range pods {
+		if pod.GetPod().NamespacedName.String() == target {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Request is pinned to a pod", "pod", target)
+			return []types.Pod{pod}
+		}
+	}
+
+	if f.strict {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Request is pinned to a pod which is not a candidate, failing it", "pod", target)
+		return []types.Pod{}
+	}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Request is pinned to a pod which is not a candidate, ignoring the pin", "pod", target)
+	return pods
+}
@@ -0,0 +1,57 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestPinPodFilter(t *testing.T) {
+	pod1 := &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "pod-1"}}}
+	pod2 := &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "pod-2"}}}
+	pods := []types.Pod{pod1, pod2}
+
+	tests := []struct {
+		name     string
+		target   string
+		strict   bool
+		wantPods []types.Pod
+	}{
+		{name: "requests without the header are not filtered", wantPods: pods},
+		{name: "present pod", target: "default/pod-2", wantPods: []types.Pod{pod2}},
+		{name: "present pod with strict", target: "default/pod-2", strict: true, wantPods: []types.Pod{pod2}},
+		{name: "absent pod with strict fails", target: "default/pod-3", strict: true, wantPods: []types.Pod{}},
+		{name: "absent pod without strict is not filtered", target: "default/pod-3", wantPods: pods},
+		{name: "pod name without namespace does not match", target: "pod-2", wantPods: pods},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := filter.NewPinPod("pin", filter.PinPodHeaderDefault, test.strict)
+			request := &types.LLMRequest{Headers: map[string]string{}}
+			if test.target != "" {
+				request.Headers[filter.PinPodHeaderDefault] = test.target
+			}
+
+			got := f.Filter(context.Background(), types.NewCycleState(), request, pods)
+			assert.Equal(t, test.wantPods, got)
+		})
+	}
+}
+
+func TestPinPodFactory(t *testing.T) {
+	plugin, err := filter.PinPodFactory("pin", json.RawMessage(`{"header": "x-pod", "strict": true}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, filter.PinPodType, plugin.TypedName().Type)
+
+	_, err = filter.PinPodFactory("pin", json.RawMessage(`{"header": ""}`), nil)
+	assert.Error(t, err)
+}
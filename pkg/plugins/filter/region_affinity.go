@@ -0,0 +1,169 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// RegionAffinityType is the type of the RegionAffinity filter
+	RegionAffinityType = "region-affinity-filter"
+
+	// RegionLabelDefault is the default pod label holding the region of a pod
+	RegionLabelDefault = "topology.kubernetes.io/region"
+	// RegionHeaderDefault is the default request header holding the region of the caller
+	RegionHeaderDefault = "x-caller-region"
+
+	// saturationQueueThresholdDefault is the default waiting queue size at which a pod is saturated
+	saturationQueueThresholdDefault = 128
+)
+
+type regionAffinityParameters struct {
+	Label                    string  `json:"label"`
+	RegionHeader             string  `json:"regionHeader"`
+	Failover                 bool    `json:"failover"`
+	SaturationQueueThreshold int     `json:"saturationQueueThreshold"`
+	SaturationKVCacheUsage   float64 `json:"saturationKVCacheUsage"`
+}
+
+var _ framework.Filter = &RegionAffinity{} // validate interface conformance
+
+// RegionAffinityFactory defines the factory function for the RegionAffinity filter.
+func RegionAffinityFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := regionAffinityParameters{
+		Label:                    RegionLabelDefault,
+		RegionHeader:             RegionHeaderDefault,
+		Failover:                 true,
+		SaturationQueueThreshold: saturationQueueThresholdDefault,
+	}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", RegionAffinityType, err)
+		}
+	}
+	return NewRegionAffinity(name, parameters.Label, parameters.RegionHeader, parameters.Failover).
+		WithSaturation(parameters.SaturationQueueThreshold, parameters.SaturationKVCacheUsage), nil
+}
+
+// NewRegionAffinity creates and returns an instance of the RegionAffinity filter
+// name - the filter name
+// labelName - the name of the label holding the region of a pod
+// regionHeader - the name of the request header holding the region of the caller
+// failover - if true requests fail over to other regions when their region is saturated
+func NewRegionAffinity(name string, labelName string, regionHeader string, failover bool) *RegionAffinity {
+	return &RegionAffinity{
+		typedName:      plugins.TypedName{Type: RegionAffinityType, Name: name},
+		labelName:      labelName,
+		regionHeader:   regionHeader,
+		failover:       failover,
+		queueThreshold: saturationQueueThresholdDefault,
+	}
+}
+
+// RegionAffinity - keeps the pods in the region of the caller, as defined by the given label and
+// request header. When all the pods in the caller's region are saturated, or none exists, requests
+// optionally fail over to the unsaturated pods of other regions. Requests without a region are
+// not filtered.
+type RegionAffinity struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// labelName defines the name of the label holding the region of a pod
+	labelName string
+	// regionHeader defines the name of the request header holding the region of the caller
+	regionHeader string
+	// failover - if true requests fail over to other regions when their region is saturated
+	failover bool
+	// queueThreshold defines the waiting queue size at which a pod is saturated
+	queueThreshold int
+	// kvCacheThreshold defines the KV-cache usage at which a pod is saturated, 0 to ignore the KV-cache usage
+	kvCacheThreshold float64
+}
+
+// TypedName returns the typed name of the plugin
+func (f *RegionAffinity) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *RegionAffinity) WithName(name string) *RegionAffinity {
+	f.typedName.Name = name
+	return f
+}
+
+// WithSaturation sets the waiting queue size and the KV-cache usage (in range of 0-1) at which a pod
+// is saturated. Non-positive values are ignored, keeping the default queue size and ignoring the
+// KV-cache usage.
+func (f *RegionAffinity) WithSaturation(queueThreshold int, kvCacheThreshold float64) *RegionAffinity {
+	if queueThreshold > 0 {
+		f.queueThreshold = queueThreshold
+	}
+	if kvCacheThreshold > 0 {
+		f.kvCacheThreshold = kvCacheThreshold
+	}
+	return f
+}
+
+// Filter keeps the pods in the region of the caller if any of them is unsaturated. Otherwise, when
+// failover is enabled, it keeps the unsaturated pods of the other regions, and falls back to the
+// saturated pods of the caller's region if all pods are saturated.
+func (f *RegionAffinity) Filter(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	if request == nil || request.Headers[f.regionHeader] == "" {
+		return pods
+	}
+	region := request.Headers[f.regionHeader]
+
+	localPods := []types.Pod{}
+	remotePods := []types.Pod{}
+	localAvailable := false
+	for _, pod := range pods {
+		if pod.GetPod().Labels[f.labelName] == region {
+			localPods = append(localPods, pod)
+			localAvailable = localAvailable || !f.saturated(pod)
+		} else {
+			remotePods = append(remotePods, pod)
+		}
+	}
+
+	if localAvailable || !f.failover {
+		return localPods
+	}
+
+	availableRemotePods := []types.Pod{}
+	for _, pod := range remotePods {
+		if !f.saturated(pod) {
+			availableRemotePods = append(availableRemotePods, pod)
+		}
+	}
+
+	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
+	switch {
+	case len(availableRemotePods) > 0:
+		loggerDebug.Info("Region is saturated, failing over to other regions", "region", region, "localPods", len(localPods))
+		return availableRemotePods
+	case len(localPods) > 0:
+		loggerDebug.Info("All regions are saturated, keeping the pods of the region", "region", region)
+		return localPods
+	default:
+		loggerDebug.Info("Region has no pods and all other regions are saturated", "region", region)
+		return remotePods
+	}
+}
+
+// saturated returns true if the given pod's waiting queue or KV-cache usage reached their thresholds
+func (f *RegionAffinity) saturated(pod types.Pod) bool {
+	metrics := pod.GetMetrics()
+	if metrics == nil {
+		return false
+	}
+	return metrics.WaitingQueueSize >= f.queueThreshold ||
+		(f.kvCacheThreshold > 0 && metrics.KVCacheUsagePercent >= f.kvCacheThreshold)
+}
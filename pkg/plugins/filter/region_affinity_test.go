@@ -0,0 +1,136 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestRegionAffinityFilter(t *testing.T) {
+	tests := []struct {
+		testName     string
+		region       string
+		failover     bool
+		pods         []types.Pod
+		expectedPods []string
+	}{
+		{
+			testName: "requests without region are not filtered",
+			failover: true,
+			pods: []types.Pod{
+				createPodWithMetrics("us-1", regionLabels("us"), backendmetrics.MetricsState{}),
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{"us-1", "eu-1"},
+		},
+		{
+			testName: "in-region routing",
+			region:   "us",
+			failover: true,
+			pods: []types.Pod{
+				createPodWithMetrics("us-1", regionLabels("us"), backendmetrics.MetricsState{}),
+				createPodWithMetrics("us-2", regionLabels("us"), backendmetrics.MetricsState{WaitingQueueSize: 20}),
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{"us-1", "us-2"},
+		},
+		{
+			testName: "cross-region failover under local saturation",
+			region:   "us",
+			failover: true,
+			pods: []types.Pod{
+				createPodWithMetrics("us-1", regionLabels("us"), backendmetrics.MetricsState{WaitingQueueSize: 10}),
+				createPodWithMetrics("us-2", regionLabels("us"), backendmetrics.MetricsState{WaitingQueueSize: 20}),
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+				createPodWithMetrics("ap-1", regionLabels("ap"), backendmetrics.MetricsState{WaitingQueueSize: 10}),
+				createPodWithMetrics("none", regionLabels(""), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{"eu-1", "none"},
+		},
+		{
+			testName: "cross-region failover without local pods",
+			region:   "us",
+			failover: true,
+			pods: []types.Pod{
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+				createPodWithMetrics("ap-1", regionLabels("ap"), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{"eu-1", "ap-1"},
+		},
+		{
+			testName: "local pods are kept when all regions are saturated",
+			region:   "us",
+			failover: true,
+			pods: []types.Pod{
+				createPodWithMetrics("us-1", regionLabels("us"), backendmetrics.MetricsState{WaitingQueueSize: 10}),
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{WaitingQueueSize: 10}),
+			},
+			expectedPods: []string{"us-1"},
+		},
+		{
+			testName: "no failover under local saturation when disabled",
+			region:   "us",
+			failover: false,
+			pods: []types.Pod{
+				createPodWithMetrics("us-1", regionLabels("us"), backendmetrics.MetricsState{WaitingQueueSize: 10}),
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{"us-1"},
+		},
+		{
+			testName: "no failover without local pods when disabled",
+			region:   "us",
+			failover: false,
+			pods: []types.Pod{
+				createPodWithMetrics("eu-1", regionLabels("eu"), backendmetrics.MetricsState{}),
+			},
+			expectedPods: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			f := filter.NewRegionAffinity("region", filter.RegionLabelDefault, filter.RegionHeaderDefault, tt.failover).WithSaturation(10, 0)
+			request := &types.LLMRequest{Headers: map[string]string{filter.RegionHeaderDefault: tt.region}}
+			filteredPods := f.Filter(context.Background(), nil, request, tt.pods)
+
+			actualPodNames := []string{}
+			for _, pod := range filteredPods {
+				actualPodNames = append(actualPodNames, pod.GetPod().NamespacedName.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedPods, actualPodNames)
+		})
+	}
+}
+
+func TestRegionAffinityKVCacheSaturation(t *testing.T) {
+	pods := []types.Pod{
+		createPodWithMetrics("us-1", map[string]string{"region": "us"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.95}),
+		createPodWithMetrics("eu-1", map[string]string{"region": "eu"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.5}),
+	}
+	request := &types.LLMRequest{Headers: map[string]string{"x-region": "us"}}
+
+	plugin, err := filter.RegionAffinityFactory("region", json.RawMessage(`{"label": "region", "regionHeader": "x-region"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Pod{pods[0]}, plugin.(framework.Filter).Filter(context.Background(), nil, request, pods))
+
+	plugin, err = filter.RegionAffinityFactory("region",
+		json.RawMessage(`{"label": "region", "regionHeader": "x-region", "saturationKVCacheUsage": 0.9}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Pod{pods[1]}, plugin.(framework.Filter).Filter(context.Background(), nil, request, pods))
+}
+
+func regionLabels(region string) map[string]string {
+	if region == "" {
+		return map[string]string{}
+	}
+	return map[string]string{filter.RegionLabelDefault: region}
+}
@@ -0,0 +1,285 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// TenantQuotaType is the type of the TenantQuota filter
+	TenantQuotaType = "tenant-quota-filter"
+
+	defaultTenantHeader              = "x-tenant-id"
+	defaultContentionQueueSize       = 5
+	defaultTenantQuota               = 1.0
+	defaultTenantQuotaRequestTimeout = 2 * time.Minute
+)
+
+type tenantQuotaParameters struct {
+	// TenantHeader is the name of the request header identifying the tenant
+	TenantHeader string `json:"tenantHeader"`
+	// Quotas maps tenants to their soft quota, the share (0-1) of the in-flight requests they may hold under contention
+	Quotas map[string]float64 `json:"quotas"`
+	// DefaultQuota is the soft quota of tenants not listed in Quotas
+	DefaultQuota float64 `json:"defaultQuota"`
+	// ContentionQueueSize is the average waiting queue size of the candidate pods at which the fleet is contended
+	ContentionQueueSize int `json:"contentionQueueSize"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Filter            = &TenantQuota{}
+	_ requestcontrol.PreRequest   = &TenantQuota{}
+	_ requestcontrol.PostResponse = &TenantQuota{}
+)
+
+// TenantQuotaFactory defines the factory function for the TenantQuota filter.
+func TenantQuotaFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := tenantQuotaParameters{
+		TenantHeader:        defaultTenantHeader,
+		DefaultQuota:        defaultTenantQuota,
+		ContentionQueueSize: defaultContentionQueueSize,
+	}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", TenantQuotaType, err)
+		}
+	}
+	if err := validateTenantQuotas(parameters.Quotas, parameters.DefaultQuota); err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", TenantQuotaType, err)
+	}
+
+	return NewTenantQuota(handle.Context(), name, parameters.TenantHeader, parameters.Quotas,
+		parameters.DefaultQuota, parameters.ContentionQueueSize), nil
+}
+
+// validateTenantQuotas returns an error if any of the given quotas is not in range of 0-1, excluding 0
+func validateTenantQuotas(quotas map[string]float64, defaultQuota float64) error {
+	if defaultQuota <= 0 || defaultQuota > 1 {
+		return errors.New("defaultQuota must be in range of 0-1, excluding 0")
+	}
+	for tenant, quota := range quotas {
+		if quota <= 0 || quota > 1 {
+			return fmt.Errorf("the quota of tenant '%s' must be in range of 0-1, excluding 0", tenant)
+		}
+	}
+	return nil
+}
+
+// NewTenantQuota creates and returns an instance of the TenantQuota filter
+// name - the filter name
+// tenantHeader - the name of the request header identifying the tenant
+// quotas - the soft quota of each tenant, as a share (0-1) of the in-flight requests
+// defaultQuota - the soft quota of tenants not listed in quotas
+// contentionQueueSize - the average waiting queue size of the candidate pods at which the fleet is contended
+func NewTenantQuota(ctx context.Context, name string, tenantHeader string, quotas map[string]float64,
+	defaultQuota float64, contentionQueueSize int) *TenantQuota {
+	requests := ttlcache.New[string, string](
+		ttlcache.WithTTL[string, string](defaultTenantQuotaRequestTimeout),
+		ttlcache.WithDisableTouchOnHit[string, string](),
+	)
+
+	filter := &TenantQuota{
+		typedName:           plugins.TypedName{Type: TenantQuotaType, Name: name},
+		tenantHeader:        tenantHeader,
+		quotas:              quotas,
+		defaultQuota:        defaultQuota,
+		contentionQueueSize: contentionQueueSize,
+		requests:            requests,
+		inFlight:            make(map[string]int),
+		mutex:               &sync.RWMutex{},
+	}
+	// release requests that never got a response
+	requests.OnEviction(func(_ context.Context, reason ttlcache.EvictionReason, item *ttlcache.Item[string, string]) {
+		if reason == ttlcache.EvictionReasonExpired {
+			filter.release(item.Value())
+		}
+	})
+
+	go requests.Start()
+	go func() {
+		<-ctx.Done()
+		requests.Stop()
+	}()
+
+	return filter
+}
+
+// TenantQuota - deprioritizes requests of tenants holding more than their soft quota of the
+// in-flight requests while the fleet is contended and another tenant with in-flight requests is
+// within its quota. Such requests are confined to the most loaded candidate pods, leaving the less
+// loaded pods to the tenants within their quota. When the fleet is not contended, all requests are
+// served freely. Requests without a tenant are never deprioritized.
+type TenantQuota struct {
+	// name defines the filter typed name
+	typedName plugins.TypedName
+	// tenantHeader defines the name of the request header identifying the tenant
+	tenantHeader string
+	// quotas defines the soft quota of each tenant
+	quotas map[string]float64
+	// defaultQuota defines the soft quota of tenants not listed in quotas
+	defaultQuota float64
+	// contentionQueueSize defines the average waiting queue size at which the fleet is contended
+	contentionQueueSize int
+
+	// requests maps the in-flight request IDs to their tenant
+	requests *ttlcache.Cache[string, string]
+	// inFlight holds the number of in-flight requests per tenant
+	inFlight map[string]int
+	total    int
+	mutex    *sync.RWMutex
+}
+
+// TypedName returns the typed name of the plugin
+func (f *TenantQuota) TypedName() plugins.TypedName {
+	return f.typedName
+}
+
+// WithName sets the name of the plugin.
+func (f *TenantQuota) WithName(name string) *TenantQuota {
+	f.typedName.Name = name
+	return f
+}
+
+// Filter keeps only the most loaded pods if the request's tenant is over its quota while the fleet
+// is contended and another tenant is within its quota. The filter never returns an empty pod set.
+func (f *TenantQuota) Filter(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
+	tenant := f.tenant(request)
+	if tenant == "" || !f.contended(pods) {
+		return pods
+	}
+
+	share, quota, competing := f.overQuota(tenant)
+	if !competing {
+		return pods
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Tenant is over its quota under contention, deprioritizing request",
+		"tenant", tenant, "share", share, "quota", quota)
+	return mostLoadedPods(pods)
+}
+
+// overQuota returns the share of the in-flight requests held by the given tenant and its quota, and
+// whether the tenant is over its quota while another tenant with in-flight requests is within its quota
+func (f *TenantQuota) overQuota(tenant string) (float64, float64, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	quota := f.quota(tenant)
+	if f.total == 0 {
+		return 0, quota, false
+	}
+	share := float64(f.inFlight[tenant]) / float64(f.total)
+	if share <= quota {
+		return share, quota, false
+	}
+
+	for other, count := range f.inFlight {
+		if other != tenant && float64(count)/float64(f.total) < f.quota(other) {
+			return share, quota, true
+		}
+	}
+	return share, quota, false
+}
+
+// quota returns the soft quota of the given tenant
+func (f *TenantQuota) quota(tenant string) float64 {
+	if quota, found := f.quotas[tenant]; found {
+		return quota
+	}
+	return f.defaultQuota
+}
+
+// PreRequest records the request as in-flight for its tenant. Requests without a request ID are not
+// tracked, as their response could not be matched with them.
+func (f *TenantQuota) PreRequest(_ context.Context, request *types.LLMRequest, _ *types.SchedulingResult, _ int) {
+	tenant := f.tenant(request)
+	if tenant == "" || request.RequestId == "" || f.requests.Has(request.RequestId) {
+		return
+	}
+
+	f.requests.Set(request.RequestId, tenant, ttlcache.DefaultTTL)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.inFlight[tenant]++
+	f.total++
+}
+
+// PostResponse releases the in-flight request of its tenant
+func (f *TenantQuota) PostResponse(_ context.Context, request *types.LLMRequest, _ *requestcontrol.Response, _ *backend.Pod) {
+	if request == nil || request.RequestId == "" {
+		return
+	}
+	if item, found := f.requests.GetAndDelete(request.RequestId); found {
+		f.release(item.Value())
+	}
+}
+
+// release decrements the number of in-flight requests of the given tenant
+func (f *TenantQuota) release(tenant string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if count, exists := f.inFlight[tenant]; exists {
+		if count <= 1 {
+			delete(f.inFlight, tenant)
+		} else {
+			f.inFlight[tenant] = count - 1
+		}
+		f.total--
+	}
+}
+
+// tenant returns the tenant of the given request, or an empty string if it has none
+func (f *TenantQuota) tenant(request *types.LLMRequest) string {
+	if request == nil {
+		return ""
+	}
+	return request.Headers[f.tenantHeader]
+}
+
+// contended returns true if the average waiting queue size of the given pods reached the contention queue size
+func (f *TenantQuota) contended(pods []types.Pod) bool {
+	if len(pods) == 0 {
+		return false
+	}
+
+	waiting := 0
+	for _, pod := range pods {
+		waiting += pod.GetMetrics().WaitingQueueSize
+	}
+	return waiting >= f.contentionQueueSize*len(pods)
+}
+
+// mostLoadedPods returns the pods whose waiting queue size is at least the average waiting queue
+// size of the given pods, which is never empty for a non empty list of pods
+func mostLoadedPods(pods []types.Pod) []types.Pod {
+	waiting := 0
+	for _, pod := range pods {
+		waiting += pod.GetMetrics().WaitingQueueSize
+	}
+
+	loadedPods := []types.Pod{}
+	for _, pod := range pods {
+		if pod.GetMetrics().WaitingQueueSize*len(pods) >= waiting {
+			loadedPods = append(loadedPods, pod)
+		}
+	}
+	return loadedPods
+}
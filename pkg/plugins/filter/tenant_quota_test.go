@@ -0,0 +1,161 @@
+package filter_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+)
+
+func TestTenantQuotaFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	idlePods := tenantQuotaPods(0, 0)
+	contendedPods := tenantQuotaPods(8, 12)
+
+	tqf := filter.NewTenantQuota(ctx, "tenant-quota", "x-tenant-id", map[string]float64{"greedy": 0.5}, 1.0, 5)
+	schedulingResult := &types.SchedulingResult{}
+
+	// greedy tenant holds 3 of the 4 in-flight requests
+	var greedyRequests []*types.LLMRequest
+	for i := range 3 {
+		request := tenantRequest("greedy", i)
+		greedyRequests = append(greedyRequests, request)
+		tqf.PreRequest(ctx, request, schedulingResult, 0)
+	}
+	tqf.PreRequest(ctx, tenantRequest("modest", 0), schedulingResult, 0)
+
+	t.Run("over-quota tenant is served freely when idle", func(t *testing.T) {
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("greedy", 10), idlePods), 2)
+	})
+
+	t.Run("over-quota tenant is confined to the most loaded pods under contention", func(t *testing.T) {
+		assert.Equal(t, contendedPods[1:], tqf.Filter(ctx, nil, tenantRequest("greedy", 10), contendedPods))
+	})
+
+	t.Run("tenant within quota is served under contention", func(t *testing.T) {
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("modest", 10), contendedPods), 2)
+	})
+
+	t.Run("request without tenant is served under contention", func(t *testing.T) {
+		assert.Len(t, tqf.Filter(ctx, nil, &types.LLMRequest{RequestId: "anonymous"}, contendedPods), 2)
+	})
+
+	t.Run("tenant back within quota once its requests complete", func(t *testing.T) {
+		for _, request := range greedyRequests[:2] {
+			tqf.PostResponse(ctx, request, &requestcontrol.Response{}, nil)
+		}
+		// greedy tenant now holds 1 of the 2 in-flight requests
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("greedy", 10), contendedPods), 2)
+	})
+}
+
+func TestTenantQuotaFilterNoCompetingTenant(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contendedPods := tenantQuotaPods(8, 12)
+	schedulingResult := &types.SchedulingResult{}
+
+	t.Run("single tenant is served freely under contention", func(t *testing.T) {
+		tqf := filter.NewTenantQuota(ctx, "tenant-quota", "x-tenant-id", map[string]float64{"alone": 0.5}, 1.0, 5)
+		tqf.PreRequest(ctx, tenantRequest("alone", 0), schedulingResult, 0)
+		tqf.PreRequest(ctx, tenantRequest("alone", 1), schedulingResult, 0)
+
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("alone", 10), contendedPods), 2)
+	})
+
+	t.Run("tenants all over quota are served freely under contention", func(t *testing.T) {
+		tqf := filter.NewTenantQuota(ctx, "tenant-quota", "x-tenant-id", nil, 0.3, 5)
+		tqf.PreRequest(ctx, tenantRequest("first", 0), schedulingResult, 0)
+		tqf.PreRequest(ctx, tenantRequest("second", 0), schedulingResult, 0)
+
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("first", 10), contendedPods), 2)
+		assert.Len(t, tqf.Filter(ctx, nil, tenantRequest("second", 10), contendedPods), 2)
+	})
+}
+
+func TestTenantQuotaFilterEmptyRequestID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contendedPods := tenantQuotaPods(8, 12)
+	schedulingResult := &types.SchedulingResult{}
+
+	tqf := filter.NewTenantQuota(ctx, "tenant-quota", "x-tenant-id", map[string]float64{"greedy": 0.5}, 1.0, 5)
+	tqf.PreRequest(ctx, tenantRequest("modest", 0), schedulingResult, 0)
+
+	// requests without a request ID are not tracked, so they neither collide nor count against the quota
+	anonymous := func() *types.LLMRequest {
+		return &types.LLMRequest{Headers: map[string]string{"x-tenant-id": "greedy"}}
+	}
+	for range 3 {
+		tqf.PreRequest(ctx, anonymous(), schedulingResult, 0)
+	}
+	assert.Len(t, tqf.Filter(ctx, nil, anonymous(), contendedPods), 2)
+
+	// releasing a request without a request ID does not release tracked requests
+	tqf.PreRequest(ctx, tenantRequest("greedy", 0), schedulingResult, 0)
+	tqf.PreRequest(ctx, tenantRequest("greedy", 1), schedulingResult, 0)
+	tqf.PostResponse(ctx, anonymous(), &requestcontrol.Response{}, nil)
+	assert.Equal(t, contendedPods[1:], tqf.Filter(ctx, nil, anonymous(), contendedPods))
+}
+
+func TestTenantQuotaFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{name: "defaults", params: `{}`},
+		{name: "valid quotas", params: `{"quotas": {"a": 0.5, "b": 1}, "defaultQuota": 0.2}`},
+		{name: "zero quota", params: `{"quotas": {"a": 0}}`, wantErr: true},
+		{name: "quota above 1", params: `{"quotas": {"a": 1.5}}`, wantErr: true},
+		{name: "zero default quota", params: `{"defaultQuota": 0}`, wantErr: true},
+		{name: "negative default quota", params: `{"defaultQuota": -0.5}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := filter.TenantQuotaFactory("tenant-quota", json.RawMessage(test.params), handle)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// tenantQuotaPods returns pods with the given waiting queue sizes
+func tenantQuotaPods(waiting ...int) []types.Pod {
+	pods := make([]types.Pod, 0, len(waiting))
+	for i, queue := range waiting {
+		pods = append(pods, &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: fmt.Sprintf("pod-%d", i+1)}},
+			MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: queue},
+		})
+	}
+	return pods
+}
+
+// tenantRequest returns a request of the given tenant with an ID derived from the given number
+func tenantRequest(tenant string, id int) *types.LLMRequest {
+	return &types.LLMRequest{
+		RequestId: fmt.Sprintf("%s-%d", tenant, id),
+		Headers:   map[string]string{"x-tenant-id": tenant},
+	}
+}
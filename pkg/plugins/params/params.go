@@ -0,0 +1,24 @@
+// Package params provides helpers for parsing the parameters of the plugins.
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Unmarshal parses the given raw parameters of a plugin into the given parameters.
+// Unlike json.Unmarshal, it fails on fields the parameters do not define, e.g. misspelled
+// parameter names, rather than silently ignoring them and leaving their defaults in place.
+func Unmarshal(rawParameters json.RawMessage, parameters any) error {
+	decoder := json.NewDecoder(bytes.NewReader(rawParameters))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(parameters); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after the parameters")
+	}
+	return nil
+}
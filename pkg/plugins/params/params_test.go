@@ -0,0 +1,43 @@
+package params_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+func TestUnmarshal(t *testing.T) {
+	type parameters struct {
+		Threshold int    `json:"threshold"`
+		Curve     string `json:"curve"`
+	}
+
+	tests := []struct {
+		name          string
+		rawParameters string
+		want          parameters
+		wantErr       string
+	}{
+		{name: "known fields", rawParameters: `{"threshold": 10, "curve": "linear"}`, want: parameters{Threshold: 10, Curve: "linear"}},
+		{name: "missing fields keep their defaults", rawParameters: `{}`, want: parameters{Threshold: 1, Curve: "linear"}},
+		{name: "misspelled field", rawParameters: `{"treshold": 10}`, wantErr: `unknown field "treshold"`},
+		{name: "invalid value", rawParameters: `{"threshold": "10"}`, wantErr: "cannot unmarshal"},
+		{name: "trailing data", rawParameters: `{"threshold": 10} {}`, wantErr: "unexpected data"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parameters{Threshold: 1, Curve: "linear"}
+			err := params.Unmarshal(json.RawMessage(test.rawParameters), &got)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package picker provides picker plugins for the epp.
+package picker
@@ -0,0 +1,134 @@
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// PowerOfTwoChoicesType is the type of the PowerOfTwoChoices picker
+	PowerOfTwoChoicesType = "power-of-two-choices-picker"
+)
+
+type powerOfTwoChoicesParameters struct {
+	// PrefixPluginName is the name of the prefix cache plugin whose state is used to detect prefix matches.
+	PrefixPluginName string `json:"prefixPluginName"`
+}
+
+// compile-time type assertion
+var _ framework.Picker = &PowerOfTwoChoices{}
+
+// PowerOfTwoChoicesFactory defines the factory function for the PowerOfTwoChoices picker.
+func PowerOfTwoChoicesFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := powerOfTwoChoicesParameters{PrefixPluginName: prefix.PrefixCachePluginType}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", PowerOfTwoChoicesType, err)
+		}
+	}
+
+	return NewPowerOfTwoChoices(parameters.PrefixPluginName).WithName(name), nil
+}
+
+// NewPowerOfTwoChoices creates a new PowerOfTwoChoices picker.
+// prefixPluginName - the name of the prefix cache plugin whose state is used to detect prefix matches
+func NewPowerOfTwoChoices(prefixPluginName string) *PowerOfTwoChoices {
+	return &PowerOfTwoChoices{
+		typedName:             plugins.TypedName{Type: PowerOfTwoChoicesType},
+		prefixPluginTypedName: plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefixPluginName},
+	}
+}
+
+// PowerOfTwoChoices picks a pod using power of two choices load balancing while honoring prefix
+// affinity: two candidates are sampled at random, and the one with the longer prefix match is
+// picked. If both have the same prefix match, the less loaded one is picked.
+// The prefix cache plugin must run in the scheduling profile for prefix matches to be considered.
+type PowerOfTwoChoices struct {
+	typedName             plugins.TypedName
+	prefixPluginTypedName plugins.TypedName
+}
+
+// TypedName returns the typed name of the plugin.
+func (p *PowerOfTwoChoices) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+// WithName sets the name of the plugin.
+func (p *PowerOfTwoChoices) WithName(name string) *PowerOfTwoChoices {
+	p.typedName.Name = name
+	return p
+}
+
+// Pick picks the better of two randomly sampled candidates.
+func (p *PowerOfTwoChoices) Pick(ctx context.Context, cycleState *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	if len(scoredPods) == 0 {
+		return &types.ProfileRunResult{TargetPods: []types.Pod{}}
+	}
+	if len(scoredPods) == 1 {
+		return &types.ProfileRunResult{TargetPods: []types.Pod{scoredPods[0]}}
+	}
+
+	first := rand.IntN(len(scoredPods))
+	second := rand.IntN(len(scoredPods) - 1)
+	if second >= first {
+		second++
+	}
+
+	prefixHits := p.prefixHits(ctx, cycleState)
+	picked := p.better(scoredPods[first], scoredPods[second], prefixHits)
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Picked pod out of two choices", "first", scoredPods[first].GetPod().NamespacedName,
+		"second", scoredPods[second].GetPod().NamespacedName, "picked", picked.GetPod().NamespacedName)
+	return &types.ProfileRunResult{TargetPods: []types.Pod{picked}}
+}
+
+// better returns the better of the given pods: the one with more prefix hits, then the one with
+// the shorter waiting queue, then the one with the lower KV-cache usage.
+func (p *PowerOfTwoChoices) better(a *types.ScoredPod, b *types.ScoredPod, prefixHits map[prefix.ServerID]int) *types.ScoredPod {
+	aHits := prefixHits[prefix.ServerID(a.GetPod().NamespacedName)]
+	bHits := prefixHits[prefix.ServerID(b.GetPod().NamespacedName)]
+	if aHits != bHits {
+		if aHits > bHits {
+			return a
+		}
+		return b
+	}
+
+	aMetrics, bMetrics := a.GetMetrics(), b.GetMetrics()
+	if aMetrics.WaitingQueueSize != bMetrics.WaitingQueueSize {
+		if aMetrics.WaitingQueueSize < bMetrics.WaitingQueueSize {
+			return a
+		}
+		return b
+	}
+	if bMetrics.KVCacheUsagePercent < aMetrics.KVCacheUsagePercent {
+		return b
+	}
+	return a
+}
+
+// prefixHits returns the number of prefix hits per pod, as computed by the prefix cache plugin
+// in the current scheduling cycle.
+func (p *PowerOfTwoChoices) prefixHits(ctx context.Context, cycleState *types.CycleState) map[prefix.ServerID]int {
+	if cycleState == nil {
+		return nil
+	}
+
+	prefixState, err := types.ReadCycleStateKey[*prefix.SchedulingContextState](cycleState, plugins.StateKey(p.prefixPluginTypedName.String()))
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Unable to read prefix state, ignoring prefix matches", "error", err)
+		return nil
+	}
+	return prefixState.PrefixCacheServers
+}
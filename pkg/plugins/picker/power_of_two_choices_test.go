@@ -0,0 +1,110 @@
+package picker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/picker"
+)
+
+func TestPowerOfTwoChoices_Pick(t *testing.T) {
+	prefixStateKey := plugins.StateKey(plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefix.PrefixCachePluginType}.String())
+
+	tests := []struct {
+		name       string
+		busyHits   int
+		idleHits   int
+		wantPicked string
+	}{
+		{
+			name:       "prefix holding candidate wins over a less loaded one",
+			busyHits:   3,
+			idleHits:   0,
+			wantPicked: "busy",
+		},
+		{
+			name:       "longer prefix match wins",
+			busyHits:   3,
+			idleHits:   1,
+			wantPicked: "busy",
+		},
+		{
+			name:       "load wins when neither has a prefix match",
+			busyHits:   0,
+			idleHits:   0,
+			wantPicked: "idle",
+		},
+		{
+			name:       "load wins on equal prefix matches",
+			busyHits:   2,
+			idleHits:   2,
+			wantPicked: "idle",
+		},
+	}
+
+	p := picker.NewPowerOfTwoChoices(prefix.PrefixCachePluginType)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			busy := createScoredPod("busy", 10, 0)
+			idle := createScoredPod("idle", 0, 0)
+
+			cycleState := types.NewCycleState()
+			cycleState.Write(prefixStateKey, &prefix.SchedulingContextState{PrefixCacheServers: map[prefix.ServerID]int{
+				prefix.ServerID(busy.GetPod().NamespacedName): test.busyHits,
+				prefix.ServerID(idle.GetPod().NamespacedName): test.idleHits,
+			}})
+
+			// with two candidates both are always sampled
+			for range 10 {
+				result := p.Pick(context.Background(), cycleState, []*types.ScoredPod{busy, idle})
+				assert.Len(t, result.TargetPods, 1)
+				assert.Equal(t, test.wantPicked, result.TargetPods[0].GetPod().NamespacedName.Name)
+			}
+		})
+	}
+}
+
+func TestPowerOfTwoChoices_SamplesTwoCandidates(t *testing.T) {
+	p := picker.NewPowerOfTwoChoices(prefix.PrefixCachePluginType)
+
+	var scoredPods []*types.ScoredPod
+	for i, name := range []string{"pod-a", "pod-b", "pod-c", "pod-d"} {
+		scoredPods = append(scoredPods, &types.ScoredPod{Pod: &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name}},
+			MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: i},
+		}})
+	}
+
+	// the most loaded pod can never win a pair, the least loaded one always wins its pairs
+	picked := map[string]int{}
+	for range 200 {
+		result := p.Pick(context.Background(), nil, scoredPods)
+		assert.Len(t, result.TargetPods, 1)
+		picked[result.TargetPods[0].GetPod().NamespacedName.Name]++
+	}
+	assert.Zero(t, picked["pod-d"])
+	assert.Greater(t, picked["pod-a"], picked["pod-c"])
+
+	assert.Empty(t, p.Pick(context.Background(), nil, nil).TargetPods)
+	assert.Equal(t, scoredPods[3], p.Pick(context.Background(), nil, scoredPods[3:]).TargetPods[0])
+}
+
+// createScoredPod creates a scored pod with the given waiting queue size and score.
+func createScoredPod(name string, waiting int, score float64) *types.ScoredPod {
+	return &types.ScoredPod{
+		Pod: &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name, Namespace: "default"}},
+			MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: waiting},
+		},
+		Score: score,
+	}
+}
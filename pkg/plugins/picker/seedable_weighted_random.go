@@ -0,0 +1,137 @@
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// SeedableWeightedRandomType is the type of the SeedableWeightedRandom picker
+	SeedableWeightedRandomType = "seedable-weighted-random-picker"
+)
+
+type seedableWeightedRandomParameters struct {
+	// MaxNumOfEndpoints is the maximal number of pods to pick.
+	MaxNumOfEndpoints int `json:"maxNumOfEndpoints"`
+	// Seed is the seed of the random source, 0 for a randomly seeded source.
+	Seed uint64 `json:"seed"`
+}
+
+// compile-time type assertion
+var _ framework.Picker = &SeedableWeightedRandom{}
+
+// SeedableWeightedRandomFactory defines the factory function for the SeedableWeightedRandom picker.
+func SeedableWeightedRandomFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := seedableWeightedRandomParameters{MaxNumOfEndpoints: picker.DefaultMaxNumOfEndpoints}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", SeedableWeightedRandomType, err)
+		}
+	}
+
+	return NewSeedableWeightedRandom(parameters.MaxNumOfEndpoints, parameters.Seed).WithName(name), nil
+}
+
+// NewSeedableWeightedRandom creates a new SeedableWeightedRandom picker.
+// maxNumOfEndpoints - the maximal number of pods to pick, non-positive values fall back to the default
+// seed - the seed of the random source, 0 for a randomly seeded source
+func NewSeedableWeightedRandom(maxNumOfEndpoints int, seed uint64) *SeedableWeightedRandom {
+	if maxNumOfEndpoints <= 0 {
+		maxNumOfEndpoints = picker.DefaultMaxNumOfEndpoints
+	}
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	return &SeedableWeightedRandom{
+		typedName:         plugins.TypedName{Type: SeedableWeightedRandomType},
+		maxNumOfEndpoints: maxNumOfEndpoints,
+		random:            rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// SeedableWeightedRandom picks pods at random, with a probability proportional to their score,
+// spreading the load over pods with similar scores instead of concentrating it on the single
+// highest scored pod. When all the scores are 0, pods are picked uniformly.
+// Unlike the IGW `weighted-random-picker`, its random source can be seeded for reproducible picks.
+type SeedableWeightedRandom struct {
+	typedName         plugins.TypedName
+	maxNumOfEndpoints int
+
+	// random is not safe for concurrent use, hence guarded by mutex
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+// TypedName returns the typed name of the plugin.
+func (p *SeedableWeightedRandom) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+// WithName sets the name of the plugin.
+func (p *SeedableWeightedRandom) WithName(name string) *SeedableWeightedRandom {
+	p.typedName.Name = name
+	return p
+}
+
+// Pick samples up to maxNumOfEndpoints pods without replacement, each with a probability
+// proportional to its score among the pods not sampled yet.
+func (p *SeedableWeightedRandom) Pick(ctx context.Context, _ *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	candidates := make([]*types.ScoredPod, len(scoredPods))
+	copy(candidates, scoredPods)
+	totalScore := 0.0
+	for _, scoredPod := range candidates {
+		totalScore += max(scoredPod.Score, 0)
+	}
+
+	targetPods := make([]types.Pod, 0, min(p.maxNumOfEndpoints, len(candidates)))
+
+	p.mutex.Lock()
+	for len(candidates) > 0 && len(targetPods) < p.maxNumOfEndpoints {
+		index := p.sample(candidates, totalScore)
+		targetPods = append(targetPods, candidates[index])
+		totalScore -= max(candidates[index].Score, 0)
+		candidates[index] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+	}
+	p.mutex.Unlock()
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Picked pods by weighted random sampling",
+		"num-of-candidates", len(scoredPods), "picked", len(targetPods))
+	return &types.ProfileRunResult{TargetPods: targetPods}
+}
+
+// sample returns the index of a pod sampled with a probability proportional to its score,
+// or uniformly if no pod has a positive score. It must be called with the mutex held.
+func (p *SeedableWeightedRandom) sample(candidates []*types.ScoredPod, totalScore float64) int {
+	if totalScore <= 0 {
+		return p.random.IntN(len(candidates))
+	}
+
+	target := p.random.Float64() * totalScore
+	for i, scoredPod := range candidates {
+		target -= max(scoredPod.Score, 0)
+		if target < 0 {
+			return i
+		}
+	}
+	// rounding errors may leave a small remainder, fall back to the last positively scored pod
+	for i := len(candidates) - 1; i > 0; i-- {
+		if candidates[i].Score > 0 {
+			return i
+		}
+	}
+	return 0
+}
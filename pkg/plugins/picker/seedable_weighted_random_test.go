@@ -0,0 +1,113 @@
+package picker_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/picker"
+)
+
+func newScoredPod(name string, score float64) *types.ScoredPod {
+	return &types.ScoredPod{
+		Pod:   &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name, Namespace: "default"}}},
+		Score: score,
+	}
+}
+
+func TestSeedableWeightedRandom_Distribution(t *testing.T) {
+	const iterations = 20000
+	const tolerance = 0.02
+
+	tests := []struct {
+		name       string
+		scores     map[string]float64
+		wantShares map[string]float64
+	}{
+		{
+			name:       "proportional to scores",
+			scores:     map[string]float64{"pod-a": 0.5, "pod-b": 0.3, "pod-c": 0.2},
+			wantShares: map[string]float64{"pod-a": 0.5, "pod-b": 0.3, "pod-c": 0.2},
+		},
+		{
+			name:       "zero scored pods are never picked",
+			scores:     map[string]float64{"pod-a": 1, "pod-b": 0, "pod-c": 1},
+			wantShares: map[string]float64{"pod-a": 0.5, "pod-b": 0, "pod-c": 0.5},
+		},
+		{
+			name:       "uniform when all scores are zero",
+			scores:     map[string]float64{"pod-a": 0, "pod-b": 0},
+			wantShares: map[string]float64{"pod-a": 0.5, "pod-b": 0.5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scoredPods := []*types.ScoredPod{}
+			for name, score := range test.scores {
+				scoredPods = append(scoredPods, newScoredPod(name, score))
+			}
+
+			p := picker.NewSeedableWeightedRandom(1, 42)
+			counts := map[string]int{}
+			for range iterations {
+				result := p.Pick(context.Background(), nil, scoredPods)
+				require.Len(t, result.TargetPods, 1)
+				counts[result.TargetPods[0].GetPod().NamespacedName.Name]++
+			}
+
+			for name, wantShare := range test.wantShares {
+				assert.InDelta(t, wantShare, float64(counts[name])/iterations, tolerance, "share of %s", name)
+			}
+		})
+	}
+}
+
+func TestSeedableWeightedRandom_Seed(t *testing.T) {
+	scoredPods := []*types.ScoredPod{newScoredPod("pod-a", 0.4), newScoredPod("pod-b", 0.3), newScoredPod("pod-c", 0.3)}
+	picks := func(seed uint64) []string {
+		p := picker.NewSeedableWeightedRandom(2, seed)
+		names := []string{}
+		for range 50 {
+			for _, pod := range p.Pick(context.Background(), nil, scoredPods).TargetPods {
+				names = append(names, pod.GetPod().NamespacedName.Name)
+			}
+		}
+		return names
+	}
+
+	first := picks(7)
+	assert.Len(t, first, 100)
+	assert.Equal(t, first, picks(7), "the same seed should reproduce the same picks")
+}
+
+func TestSeedableWeightedRandom_MaxNumOfEndpoints(t *testing.T) {
+	scoredPods := []*types.ScoredPod{newScoredPod("pod-a", 0.9), newScoredPod("pod-b", 0), newScoredPod("pod-c", 0.1)}
+
+	result := picker.NewSeedableWeightedRandom(5, 1).Pick(context.Background(), nil, scoredPods)
+	names := []string{}
+	for _, pod := range result.TargetPods {
+		names = append(names, pod.GetPod().NamespacedName.Name)
+	}
+	assert.ElementsMatch(t, []string{"pod-a", "pod-b", "pod-c"}, names)
+	assert.Equal(t, "pod-b", names[2], "zero scored pods are picked last")
+
+	result = picker.NewSeedableWeightedRandom(1, 1).Pick(context.Background(), nil, []*types.ScoredPod{})
+	assert.Empty(t, result.TargetPods)
+}
+
+func TestSeedableWeightedRandomFactory(t *testing.T) {
+	plugin, err := picker.SeedableWeightedRandomFactory("spread", json.RawMessage(`{"maxNumOfEndpoints": 2, "seed": 3}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "spread", plugin.TypedName().Name)
+	assert.Equal(t, picker.SeedableWeightedRandomType, plugin.TypedName().Type)
+
+	_, err = picker.SeedableWeightedRandomFactory("spread", json.RawMessage(`{"seed": -1}`), nil)
+	assert.Error(t, err)
+}
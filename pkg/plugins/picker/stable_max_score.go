@@ -0,0 +1,124 @@
+package picker
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// StableMaxScoreType is the type of the StableMaxScore picker
+	StableMaxScoreType = "stable-max-score-picker"
+)
+
+type stableMaxScoreParameters struct {
+	// MaxNumOfEndpoints is the maximal number of pods to pick.
+	MaxNumOfEndpoints int `json:"maxNumOfEndpoints"`
+	// TieBreak enables the deterministic ordering of pods with equal scores.
+	TieBreak bool `json:"tieBreak"`
+}
+
+// compile-time type assertion
+var _ framework.Picker = &StableMaxScore{}
+
+// StableMaxScoreFactory defines the factory function for the StableMaxScore picker.
+func StableMaxScoreFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := stableMaxScoreParameters{MaxNumOfEndpoints: picker.DefaultMaxNumOfEndpoints, TieBreak: true}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", StableMaxScoreType, err)
+		}
+	}
+
+	return NewStableMaxScore(parameters.MaxNumOfEndpoints, parameters.TieBreak).WithName(name), nil
+}
+
+// NewStableMaxScore creates a new StableMaxScore picker.
+// maxNumOfEndpoints - the maximal number of pods to pick, non-positive values fall back to the default
+// tieBreak - if true pods with equal scores are ordered by their namespaced name, otherwise randomly
+func NewStableMaxScore(maxNumOfEndpoints int, tieBreak bool) *StableMaxScore {
+	if maxNumOfEndpoints <= 0 {
+		maxNumOfEndpoints = picker.DefaultMaxNumOfEndpoints
+	}
+
+	return &StableMaxScore{
+		typedName:         plugins.TypedName{Type: StableMaxScoreType},
+		maxScorePicker:    picker.NewMaxScorePicker(maxNumOfEndpoints),
+		maxNumOfEndpoints: maxNumOfEndpoints,
+		tieBreak:          tieBreak,
+	}
+}
+
+// StableMaxScore picks the pods with the maximal score, like the max-score-picker, but breaks ties
+// deterministically by the pods' namespaced names instead of randomly. This keeps the selection
+// stable across requests when several pods share the maximal score, e.g. while all caches are empty.
+// When the tie break is disabled, picking is delegated to the max-score-picker.
+type StableMaxScore struct {
+	typedName         plugins.TypedName
+	maxScorePicker    *picker.MaxScorePicker
+	maxNumOfEndpoints int
+	tieBreak          bool
+}
+
+// TypedName returns the typed name of the plugin.
+func (p *StableMaxScore) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+// WithName sets the name of the plugin.
+func (p *StableMaxScore) WithName(name string) *StableMaxScore {
+	p.typedName.Name = name
+	return p
+}
+
+// Pick picks the pods with the maximal score, ordering pods with equal scores by their namespaced name.
+func (p *StableMaxScore) Pick(ctx context.Context, cycleState *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	if !p.tieBreak {
+		return p.maxScorePicker.Pick(ctx, cycleState, scoredPods)
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Selecting pods from candidates sorted by max score and name",
+		"max-num-of-endpoints", p.maxNumOfEndpoints, "num-of-candidates", len(scoredPods))
+
+	// a single pod is picked by a linear scan, saving the sort
+	if p.maxNumOfEndpoints == 1 && len(scoredPods) > 0 {
+		best := scoredPods[0]
+		for _, scoredPod := range scoredPods[1:] {
+			if compareScoredPods(scoredPod, best) < 0 {
+				best = scoredPod
+			}
+		}
+		return &types.ProfileRunResult{TargetPods: []types.Pod{best}}
+	}
+
+	sortedPods := slices.Clone(scoredPods)
+	slices.SortFunc(sortedPods, compareScoredPods)
+	if p.maxNumOfEndpoints < len(sortedPods) {
+		sortedPods = sortedPods[:p.maxNumOfEndpoints]
+	}
+
+	targetPods := make([]types.Pod, len(sortedPods))
+	for i, scoredPod := range sortedPods {
+		targetPods[i] = scoredPod
+	}
+	return &types.ProfileRunResult{TargetPods: targetPods}
+}
+
+// compareScoredPods orders pods by descending score, then by ascending namespaced name.
+func compareScoredPods(a *types.ScoredPod, b *types.ScoredPod) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.GetPod().NamespacedName.String(), b.GetPod().NamespacedName.String())
+}
@@ -0,0 +1,96 @@
+package picker_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/picker"
+)
+
+func TestStableMaxScore_Pick(t *testing.T) {
+	pickedNames := func(result *types.ProfileRunResult) []string {
+		names := []string{}
+		for _, pod := range result.TargetPods {
+			names = append(names, pod.GetPod().NamespacedName.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name              string
+		maxNumOfEndpoints int
+		scoredPods        []*types.ScoredPod
+		wantPicked        []string
+	}{
+		{
+			name:              "equal scores pick the first by name",
+			maxNumOfEndpoints: 1,
+			scoredPods:        []*types.ScoredPod{createScoredPod("pod-c", 0, 0.5), createScoredPod("pod-a", 0, 0.5), createScoredPod("pod-b", 0, 0.5)},
+			wantPicked:        []string{"pod-a"},
+		},
+		{
+			name:              "higher score wins over name",
+			maxNumOfEndpoints: 1,
+			scoredPods:        []*types.ScoredPod{createScoredPod("pod-c", 0, 0.9), createScoredPod("pod-a", 0, 0.5), createScoredPod("pod-b", 0, 0.5)},
+			wantPicked:        []string{"pod-c"},
+		},
+		{
+			name:              "multiple endpoints ordered by score then name",
+			maxNumOfEndpoints: 2,
+			scoredPods:        []*types.ScoredPod{createScoredPod("pod-c", 0, 0.5), createScoredPod("pod-a", 0, 0.1), createScoredPod("pod-b", 0, 0.5)},
+			wantPicked:        []string{"pod-b", "pod-c"},
+		},
+		{
+			name:              "no candidates",
+			maxNumOfEndpoints: 1,
+			scoredPods:        []*types.ScoredPod{},
+			wantPicked:        []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := picker.NewStableMaxScore(test.maxNumOfEndpoints, true)
+			for range 10 {
+				result := p.Pick(context.Background(), nil, test.scoredPods)
+				assert.Equal(t, test.wantPicked, pickedNames(result))
+			}
+		})
+	}
+}
+
+func TestStableMaxScore_NoTieBreak(t *testing.T) {
+	scoredPods := []*types.ScoredPod{}
+	for _, name := range []string{"pod-a", "pod-b", "pod-c"} {
+		scoredPods = append(scoredPods, &types.ScoredPod{
+			Pod:   &types.PodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name}}},
+			Score: 0.5,
+		})
+	}
+
+	p := picker.NewStableMaxScore(1, false)
+	picked := map[string]bool{}
+	for range 100 {
+		result := p.Pick(context.Background(), nil, scoredPods)
+		require.Len(t, result.TargetPods, 1)
+		picked[result.TargetPods[0].GetPod().NamespacedName.Name] = true
+	}
+	assert.Greater(t, len(picked), 1, "ties should be broken randomly")
+}
+
+func TestStableMaxScoreFactory(t *testing.T) {
+	plugin, err := picker.StableMaxScoreFactory("stable", json.RawMessage(`{"maxNumOfEndpoints": 2}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "stable", plugin.TypedName().Name)
+	assert.Equal(t, picker.StableMaxScoreType, plugin.TypedName().Type)
+
+	_, err = picker.StableMaxScoreFactory("stable", json.RawMessage(`{"tieBreak": "yes"}`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package prerequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// DecodeHeaderHandlerType is the type of the DecodeHeaderHandler
+	DecodeHeaderHandlerType = "decode-header-handler"
+	// defaultDecodePodHeader is the default header name used to indicate Decode worker <ip:port>
+	defaultDecodePodHeader = "x-decoder-host-port"
+
+	defaultDecodeProfile = "decode"
+)
+
+type decodeHeaderHandlerParameters struct {
+	DecodeProfile string `json:"decodeProfile"`
+	Header        string `json:"header"`
+}
+
+// compile-time type assertion
+var _ requestcontrol.PreRequest = &DecodeHeaderHandler{}
+
+// DecodeHeaderHandlerFactory defines the factory function for the DecodeHeaderHandler
+func DecodeHeaderHandlerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := decodeHeaderHandlerParameters{
+		DecodeProfile: defaultDecodeProfile,
+		Header:        defaultDecodePodHeader,
+	}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' pre-request plugin - %w", DecodeHeaderHandlerType, err)
+		}
+	}
+	if parameters.Header == "" {
+		return nil, fmt.Errorf("invalid parameters of the '%s' pre-request plugin - 'header' must be set", DecodeHeaderHandlerType)
+	}
+	return NewDecodeHeaderHandler(parameters.DecodeProfile, parameters.Header).WithName(name), nil
+}
+
+// NewDecodeHeaderHandler initializes a new DecodeHeaderHandler and returns its pointer.
+// decodeProfile - the name of the decode scheduling profile
+// header - the name of the header set with the decode worker
+func NewDecodeHeaderHandler(decodeProfile string, header string) *DecodeHeaderHandler {
+	return &DecodeHeaderHandler{
+		typedName:     plugins.TypedName{Type: DecodeHeaderHandlerType},
+		decodeProfile: decodeProfile,
+		header:        header,
+	}
+}
+
+// DecodeHeaderHandler PreRequest plugin
+type DecodeHeaderHandler struct {
+	typedName     plugins.TypedName
+	decodeProfile string
+	header        string
+}
+
+// TypedName returns the typed name of the plugin.
+func (p *DecodeHeaderHandler) TypedName() plugins.TypedName {
+	return p.typedName
+}
+
+// WithName sets the name of the plugin.
+func (p *DecodeHeaderHandler) WithName(name string) *DecodeHeaderHandler {
+	p.typedName.Name = name
+	return p
+}
+
+// PreRequest wires decode SchedulerProfile result into a header to indicate the decode worker,
+// for components which cannot rely on the endpoint selected by Envoy. The first selected decode
+// pod with a usable address is set in the header; if none of them has one, the header is not set.
+func (p *DecodeHeaderHandler) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, targetPort int) {
+	if _, found := request.Headers[p.header]; found {
+		request.Headers[p.header] = "" // clear header, if already set
+	}
+
+	decodeProfileRunResult, exists := schedulingResult.ProfileResults[p.decodeProfile]
+	if !exists || decodeProfileRunResult == nil || len(decodeProfileRunResult.TargetPods) == 0 {
+		return // decode profile failed to run, no-op in this case
+	}
+
+	for _, pod := range decodeProfileRunResult.TargetPods {
+		decodePod := pod.GetPod()
+		if !isRoutableAddress(decodePod.Address) {
+			log.FromContext(ctx).Info("Selected decode pod has no usable address, skipping it",
+				"pod", decodePod.NamespacedName, "address", decodePod.Address)
+			continue
+		}
+		request.Headers[p.header] = net.JoinHostPort(decodePod.Address, strconv.Itoa(targetPort)) // in the form of <ip:port>
+		return
+	}
+
+	log.FromContext(ctx).Info("No selected decode pod has a usable address, not setting the decode header")
+}
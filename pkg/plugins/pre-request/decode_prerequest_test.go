@@ -0,0 +1,98 @@
+package prerequest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	prerequest "github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/pre-request"
+)
+
+const decodePodHeader = "x-decoder-host-port"
+
+func TestDecodeHeaderHandler_PreRequest(t *testing.T) {
+	tests := []struct {
+		testName       string
+		profileResults map[string]*types.ProfileRunResult
+		expectedHeader string
+	}{
+		{
+			testName: "decode only",
+			profileResults: map[string]*types.ProfileRunResult{
+				"decode": {TargetPods: []types.Pod{createPod("decode", "10.0.0.2")}},
+			},
+			expectedHeader: "10.0.0.2:8000",
+		},
+		{
+			testName: "prefill and decode",
+			profileResults: map[string]*types.ProfileRunResult{
+				"prefill": {TargetPods: []types.Pod{createPod("prefill", "10.0.0.1")}},
+				"decode":  {TargetPods: []types.Pod{createPod("decode", "10.0.0.2")}},
+			},
+			expectedHeader: "10.0.0.2:8000",
+		},
+		{
+			testName: "IPv6 decode pod address",
+			profileResults: map[string]*types.ProfileRunResult{
+				"decode": {TargetPods: []types.Pod{createPod("decode", "fd00::2")}},
+			},
+			expectedHeader: "[fd00::2]:8000",
+		},
+		{
+			testName: "unroutable decode pod is skipped",
+			profileResults: map[string]*types.ProfileRunResult{
+				"decode": {TargetPods: []types.Pod{createPod("unroutable", "0.0.0.0"), createPod("decode", "10.0.0.2")}},
+			},
+			expectedHeader: "10.0.0.2:8000",
+		},
+		{
+			testName: "no decode pod with a usable address clears the header",
+			profileResults: map[string]*types.ProfileRunResult{
+				"decode": {TargetPods: []types.Pod{createPod("decode", "")}},
+			},
+			expectedHeader: "",
+		},
+		{
+			testName: "decode profile absent clears the header",
+			profileResults: map[string]*types.ProfileRunResult{
+				"prefill": {TargetPods: []types.Pod{createPod("prefill", "10.0.0.1")}},
+			},
+			expectedHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			schedulingResult := &types.SchedulingResult{PrimaryProfileName: "decode", ProfileResults: tt.profileResults}
+
+			request := &types.LLMRequest{Headers: map[string]string{decodePodHeader: "stale:1"}}
+			prerequest.NewDecodeHeaderHandler("decode", decodePodHeader).PreRequest(context.Background(), request, schedulingResult, 8000)
+
+			assert.Equal(t, tt.expectedHeader, request.Headers[decodePodHeader])
+		})
+	}
+}
+
+func TestDecodeHeaderHandlerFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+
+	plugin, err := prerequest.DecodeHeaderHandlerFactory("decode-header", nil, handle)
+	assert.NoError(t, err)
+	assert.Equal(t, plugins.TypedName{Type: prerequest.DecodeHeaderHandlerType, Name: "decode-header"}, plugin.TypedName())
+
+	request := &types.LLMRequest{Headers: map[string]string{}}
+	plugin.(*prerequest.DecodeHeaderHandler).PreRequest(context.Background(), request, &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"decode": {TargetPods: []types.Pod{&types.PodMetrics{Pod: &backend.Pod{Address: "10.0.0.2"}}}},
+		},
+	}, 8000)
+	assert.Equal(t, "10.0.0.2:8000", request.Headers[decodePodHeader], "default header")
+
+	_, err = prerequest.DecodeHeaderHandlerFactory("decode-header", json.RawMessage(`{"header": ""}`), handle)
+	assert.Error(t, err)
+}
@@ -7,10 +7,17 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
@@ -18,42 +25,69 @@ const (
 	PrefillHeaderHandlerType = "prefill-header-handler"
 	// prefillPodHeader is the header name used to indicate Prefill worker <ip:port>
 	prefillPodHeader = "x-prefiller-host-port"
+	// prefillPodResponseHeader is the response header name used to indicate the names of the Prefill workers
+	prefillPodResponseHeader = "x-prefiller-pod"
+
+	defaultPrefillProfile  = "prefill"
+	defaultMaxPrefillHosts = 1
 
-	defaultPrefillProfile = "prefill"
+	// prefillPodsTimeout bounds how long the prefill pods of a request are kept until its response
+	prefillPodsTimeout = 5 * time.Minute
+	// maxPendingPrefillPods bounds the number of requests whose prefill pods are kept until their response
+	maxPendingPrefillPods = 10000
 )
 
 type prefillHeaderHandlerParameters struct {
-	PrefillProfile string `json:"prefillProfile"`
+	PrefillProfile  string `json:"prefillProfile"`
+	MaxPrefillHosts int    `json:"maxPrefillHosts"`
 }
 
-// compile-time type assertion
-var _ requestcontrol.PreRequest = &PrefillHeaderHandler{}
+// compile-time type assertions
+var (
+	_ requestcontrol.PreRequest   = &PrefillHeaderHandler{}
+	_ requestcontrol.PostResponse = &PrefillHeaderHandler{}
+)
 
 // PrefillHeaderHandlerFactory  defines the factory function for the PrefillHeaderHandler
 func PrefillHeaderHandlerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
 	parameters := prefillHeaderHandlerParameters{
-		PrefillProfile: defaultPrefillProfile,
+		PrefillProfile:  defaultPrefillProfile,
+		MaxPrefillHosts: defaultMaxPrefillHosts,
 	}
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse the parameters of the '%s' pre-request plugin - %w", PrefillHeaderHandlerType, err)
 		}
 	}
-	return NewPrefillHeaderHandler(parameters.PrefillProfile).WithName(name), nil
+	if parameters.MaxPrefillHosts < 1 {
+		return nil, fmt.Errorf("invalid parameters of the '%s' pre-request plugin - 'maxPrefillHosts' must be at least 1", PrefillHeaderHandlerType)
+	}
+	return NewPrefillHeaderHandler(parameters.PrefillProfile).WithMaxPrefillHosts(parameters.MaxPrefillHosts).WithName(name), nil
 }
 
 // NewPrefillHeaderHandler initializes a new PrefillHeaderHandler and returns its pointer.
 func NewPrefillHeaderHandler(prefillProfile string) *PrefillHeaderHandler {
 	return &PrefillHeaderHandler{
-		typedName:      plugins.TypedName{Type: PrefillHeaderHandlerType},
-		prefillProfile: prefillProfile,
+		typedName:       plugins.TypedName{Type: PrefillHeaderHandlerType},
+		prefillProfile:  prefillProfile,
+		maxPrefillHosts: defaultMaxPrefillHosts,
+		prefillPods: ttlcache.New[string, string](
+			ttlcache.WithTTL[string, string](prefillPodsTimeout),
+			ttlcache.WithCapacity[string, string](maxPendingPrefillPods),
+			ttlcache.WithDisableTouchOnHit[string, string](),
+		),
 	}
 }
 
-// PrefillHeaderHandler PreRequest plugin
+// PrefillHeaderHandler PreRequest and PostResponse plugin
 type PrefillHeaderHandler struct {
-	typedName      plugins.TypedName
-	prefillProfile string
+	typedName       plugins.TypedName
+	prefillProfile  string
+	maxPrefillHosts int
+
+	// prefillPods holds the names of the prefill pods set in the header, keyed by request ID,
+	// until the response of the request is received
+	prefillPods *ttlcache.Cache[string, string]
 }
 
 // TypedName returns the typed name of the plugin.
@@ -67,17 +101,63 @@ func (p *PrefillHeaderHandler) WithName(name string) *PrefillHeaderHandler {
 	return p
 }
 
-// PreRequest wires prefill SchedulerProfile result into a header to indicate prefill worker
-func (p *PrefillHeaderHandler) PreRequest(_ context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, targetPort int) {
+// WithMaxPrefillHosts sets the maximal number of prefill workers set in the header.
+func (p *PrefillHeaderHandler) WithMaxPrefillHosts(maxPrefillHosts int) *PrefillHeaderHandler {
+	p.maxPrefillHosts = maxPrefillHosts
+	return p
+}
+
+// PreRequest wires prefill SchedulerProfile result into a header to indicate prefill workers.
+// Up to maxPrefillHosts selected prefill pods are set in the header as a comma separated list, in
+// the order selected. Prefill pods with no usable address are skipped; if none of them has one,
+// the header is not set and the request falls back to decode only.
+func (p *PrefillHeaderHandler) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, targetPort int) {
 	if _, found := request.Headers[prefillPodHeader]; found {
 		request.Headers[prefillPodHeader] = "" // clear header, if already set
 	}
 
 	prefillProfileRunResult, exists := schedulingResult.ProfileResults[p.prefillProfile]
-	if !exists {
+	if !exists || prefillProfileRunResult == nil || len(prefillProfileRunResult.TargetPods) == 0 {
 		return // prefill profile failed to run or we chose not to run it, no-op in this case
 	}
 
-	prefillHostPort := net.JoinHostPort(prefillProfileRunResult.TargetPods[0].GetPod().Address, strconv.Itoa(targetPort))
-	request.Headers[prefillPodHeader] = prefillHostPort // in the form of <ip:port>
+	prefillHostPorts := make([]string, 0, p.maxPrefillHosts)
+	prefillPodNames := make([]string, 0, p.maxPrefillHosts)
+	for _, pod := range prefillProfileRunResult.TargetPods {
+		if len(prefillHostPorts) == p.maxPrefillHosts {
+			break
+		}
+		prefillPod := pod.GetPod()
+		if !isRoutableAddress(prefillPod.Address) {
+			log.FromContext(ctx).Info("Selected prefill pod has no usable address, skipping it",
+				"pod", prefillPod.NamespacedName, "address", prefillPod.Address)
+			continue
+		}
+		prefillHostPorts = append(prefillHostPorts, net.JoinHostPort(prefillPod.Address, strconv.Itoa(targetPort)))
+		prefillPodNames = append(prefillPodNames, prefillPod.NamespacedName.Name)
+	}
+
+	if len(prefillHostPorts) == 0 {
+		log.FromContext(ctx).Info("No selected prefill pod has a usable address, falling back to decode only")
+		return
+	}
+
+	request.Headers[prefillPodHeader] = strings.Join(prefillHostPorts, ",") // in the form of <ip:port>[,<ip:port>...]
+	p.prefillPods.Set(request.RequestId, strings.Join(prefillPodNames, ","), ttlcache.DefaultTTL)
+}
+
+// PostResponse sets the names of the prefill pods of the request in a response header, to indicate
+// which prefill workers were chosen. It is a no-op for requests which were not prefilled.
+func (p *PrefillHeaderHandler) PostResponse(_ context.Context, request *types.LLMRequest, response *requestcontrol.Response, _ *backend.Pod) {
+	item, found := p.prefillPods.GetAndDelete(request.RequestId)
+	if !found || response.Headers == nil {
+		return
+	}
+	response.Headers[prefillPodResponseHeader] = item.Value()
+}
+
+// isRoutableAddress returns true if the given pod address is a valid, specified IP address
+func isRoutableAddress(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && !ip.IsUnspecified()
 }
@@ -0,0 +1,251 @@
+package prerequest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	prerequest "github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/pre-request"
+)
+
+const (
+	prefillPodHeader         = "x-prefiller-host-port"
+	prefillPodResponseHeader = "x-prefiller-pod"
+)
+
+func TestPrefillHeaderHandler_PreRequest(t *testing.T) {
+	tests := []struct {
+		testName       string
+		prefillAddress string
+		runPrefill     bool
+		expectedHeader string
+	}{
+		{
+			testName:       "valid prefill pod address",
+			prefillAddress: "10.0.0.1",
+			runPrefill:     true,
+			expectedHeader: "10.0.0.1:8000",
+		},
+		{
+			testName:       "valid IPv6 prefill pod address",
+			prefillAddress: "fd00::1",
+			runPrefill:     true,
+			expectedHeader: "[fd00::1]:8000",
+		},
+		{
+			testName:       "prefill pod without address falls back to decode only",
+			prefillAddress: "",
+			runPrefill:     true,
+			expectedHeader: "",
+		},
+		{
+			testName:       "prefill pod with unroutable address falls back to decode only",
+			prefillAddress: "0.0.0.0",
+			runPrefill:     true,
+			expectedHeader: "",
+		},
+		{
+			testName:       "prefill pod with malformed address falls back to decode only",
+			prefillAddress: "not-an-ip",
+			runPrefill:     true,
+			expectedHeader: "",
+		},
+		{
+			testName:       "prefill not run",
+			runPrefill:     false,
+			expectedHeader: "",
+		},
+	}
+
+	decodePod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "decode"}, Address: "10.0.0.2"},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			schedulingResult := &types.SchedulingResult{
+				PrimaryProfileName: "decode",
+				ProfileResults: map[string]*types.ProfileRunResult{
+					"decode": {TargetPods: []types.Pod{decodePod}},
+				},
+			}
+			if tt.runPrefill {
+				prefillPod := &types.PodMetrics{
+					Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "prefill"}, Address: tt.prefillAddress},
+					MetricsState: &backendmetrics.MetricsState{},
+				}
+				schedulingResult.ProfileResults["prefill"] = &types.ProfileRunResult{TargetPods: []types.Pod{prefillPod}}
+			}
+
+			request := &types.LLMRequest{Headers: map[string]string{prefillPodHeader: "stale:1"}}
+			prerequest.NewPrefillHeaderHandler("prefill").PreRequest(context.Background(), request, schedulingResult, 8000)
+
+			assert.Equal(t, tt.expectedHeader, request.Headers[prefillPodHeader])
+		})
+	}
+}
+
+func TestPrefillHeaderHandler_MaxPrefillHosts(t *testing.T) {
+	tests := []struct {
+		testName         string
+		prefillAddresses []string
+		maxPrefillHosts  int
+		expectedHeader   string
+	}{
+		{
+			testName:         "no prefill pods",
+			prefillAddresses: []string{},
+			maxPrefillHosts:  2,
+			expectedHeader:   "",
+		},
+		{
+			testName:         "single prefill pod",
+			prefillAddresses: []string{"10.0.0.1"},
+			maxPrefillHosts:  2,
+			expectedHeader:   "10.0.0.1:8000",
+		},
+		{
+			testName:         "multiple prefill pods limited to the first by default",
+			prefillAddresses: []string{"10.0.0.1", "10.0.0.3"},
+			maxPrefillHosts:  1,
+			expectedHeader:   "10.0.0.1:8000",
+		},
+		{
+			testName:         "multiple prefill pods",
+			prefillAddresses: []string{"10.0.0.1", "fd00::1", "10.0.0.3"},
+			maxPrefillHosts:  3,
+			expectedHeader:   "10.0.0.1:8000,[fd00::1]:8000,10.0.0.3:8000",
+		},
+		{
+			testName:         "multiple prefill pods limited to max hosts",
+			prefillAddresses: []string{"10.0.0.1", "fd00::1", "10.0.0.3"},
+			maxPrefillHosts:  2,
+			expectedHeader:   "10.0.0.1:8000,[fd00::1]:8000",
+		},
+		{
+			testName:         "prefill pods without usable address are skipped",
+			prefillAddresses: []string{"", "10.0.0.1", "0.0.0.0", "10.0.0.3"},
+			maxPrefillHosts:  2,
+			expectedHeader:   "10.0.0.1:8000,10.0.0.3:8000",
+		},
+		{
+			testName:         "no prefill pod with usable address falls back to decode only",
+			prefillAddresses: []string{"", "0.0.0.0"},
+			maxPrefillHosts:  2,
+			expectedHeader:   "",
+		},
+	}
+
+	decodePod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "decode"}, Address: "10.0.0.2"},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			prefillPods := make([]types.Pod, 0, len(tt.prefillAddresses))
+			for i, address := range tt.prefillAddresses {
+				prefillPods = append(prefillPods, &types.PodMetrics{
+					Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: fmt.Sprintf("prefill-%d", i)}, Address: address},
+					MetricsState: &backendmetrics.MetricsState{},
+				})
+			}
+			schedulingResult := &types.SchedulingResult{
+				PrimaryProfileName: "decode",
+				ProfileResults: map[string]*types.ProfileRunResult{
+					"decode":  {TargetPods: []types.Pod{decodePod}},
+					"prefill": {TargetPods: prefillPods},
+				},
+			}
+
+			request := &types.LLMRequest{Headers: map[string]string{prefillPodHeader: "stale:1"}}
+			handler := prerequest.NewPrefillHeaderHandler("prefill").WithMaxPrefillHosts(tt.maxPrefillHosts)
+			handler.PreRequest(context.Background(), request, schedulingResult, 8000)
+
+			assert.Equal(t, tt.expectedHeader, request.Headers[prefillPodHeader])
+		})
+	}
+}
+
+func TestPrefillHeaderHandlerFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+
+	_, err := prerequest.PrefillHeaderHandlerFactory("prefill-header", json.RawMessage(`{"maxPrefillHosts": 2}`), handle)
+	assert.NoError(t, err)
+
+	_, err = prerequest.PrefillHeaderHandlerFactory("prefill-header", json.RawMessage(`{"maxPrefillHosts": 0}`), handle)
+	assert.Error(t, err)
+}
+
+func TestPrefillHeaderHandler_PostResponse(t *testing.T) {
+	ctx := context.Background()
+
+	decodePod := createPod("decode", "10.0.0.2")
+
+	tests := []struct {
+		testName       string
+		prefillPods    []types.Pod
+		expectedHeader string
+	}{
+		{
+			testName:       "prefill pod is set in the response of PD requests",
+			prefillPods:    []types.Pod{createPod("prefill-1", "10.0.0.1")},
+			expectedHeader: "prefill-1",
+		},
+		{
+			testName:       "all prefill pods set in the request header are set in the response",
+			prefillPods:    []types.Pod{createPod("prefill-1", "10.0.0.1"), createPod("prefill-2", ""), createPod("prefill-3", "10.0.0.3")},
+			expectedHeader: "prefill-1,prefill-3",
+		},
+		{
+			testName: "no header in the response of decode only requests",
+		},
+		{
+			testName:    "no header in the response of requests falling back to decode only",
+			prefillPods: []types.Pod{createPod("prefill-1", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			schedulingResult := &types.SchedulingResult{
+				PrimaryProfileName: "decode",
+				ProfileResults: map[string]*types.ProfileRunResult{
+					"decode": {TargetPods: []types.Pod{decodePod}},
+				},
+			}
+			if tt.prefillPods != nil {
+				schedulingResult.ProfileResults["prefill"] = &types.ProfileRunResult{TargetPods: tt.prefillPods}
+			}
+
+			handler := prerequest.NewPrefillHeaderHandler("prefill").WithMaxPrefillHosts(2)
+			request := &types.LLMRequest{RequestId: "request", Headers: map[string]string{}}
+			handler.PreRequest(ctx, request, schedulingResult, 8000)
+
+			response := &requestcontrol.Response{RequestId: "request", Headers: map[string]string{}}
+			handler.PostResponse(ctx, request, response, decodePod.GetPod())
+
+			header, found := response.Headers[prefillPodResponseHeader]
+			assert.Equal(t, tt.expectedHeader != "", found)
+			assert.Equal(t, tt.expectedHeader, header)
+		})
+	}
+}
+
+// Helper functions
+func createPod(name string, address string) types.Pod {
+	return &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name}, Address: address},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+}
@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"context"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/metrics"
+)
+
+const (
+	// pdDecisionStateKey is the cycle state key of the pdDecisionState
+	pdDecisionStateKey = plugins.StateKey("pd-decision")
+
+	// ScorerScoresStateKey is the cycle state key of the ScorerScoresState, written by the traced
+	// scorers for the scheduling decision log
+	ScorerScoresStateKey = plugins.StateKey("scorer-scores")
+
+	// the reasons for running, or not running, the prefill profile of a request
+	pdDecisionPrefill        = "prefill"
+	pdDecisionForcedPrefill  = "forced-prefill"
+	pdDecisionForcedDecode   = "forced-decode"
+	pdDecisionDecodeBypass   = "decode-lightly-loaded"
+	pdDecisionBelowThreshold = "below-threshold"
+	// pdDecisionDecodeOnly is used when prefill was not considered, e.g. since decode failed
+	pdDecisionDecodeOnly = "decode-only"
+
+	// the PD decisions counted by the PD decisions metric
+	pdDecisionMetricPrefill    = "prefill"
+	pdDecisionMetricDecodeOnly = "decode_only"
+)
+
+// pdDecisionState holds the reason for running, or not running, the prefill profile of a request.
+type pdDecisionState struct {
+	decision string
+}
+
+// Clone returns a copy of the state, as stored in the cycle state.
+func (s *pdDecisionState) Clone() plugins.StateData {
+	return &pdDecisionState{decision: s.decision}
+}
+
+// ScorerScoresState holds the scores given to each pod by each traced scorer in a scheduling cycle.
+type ScorerScoresState struct {
+	// Scores maps pod names to the scores given to the pod by each traced scorer
+	Scores map[string]map[string]float64
+}
+
+// Clone returns a copy of the state, as stored in the cycle state.
+func (s *ScorerScoresState) Clone() plugins.StateData {
+	scores := make(map[string]map[string]float64, len(s.Scores))
+	for podName, podScores := range s.Scores {
+		scores[podName] = maps.Clone(podScores)
+	}
+	return &ScorerScoresState{Scores: scores}
+}
+
+// recordDecision records the reason for running, or not running, the prefill profile of a request
+// in the cycle state, for the scheduling decision log, and counts the decision.
+func (h *PdProfileHandler) recordDecision(cycleState *types.CycleState, decision string) {
+	if cycleState != nil {
+		cycleState.Write(pdDecisionStateKey, &pdDecisionState{decision: decision})
+	}
+
+	metricDecision := pdDecisionMetricDecodeOnly
+	if decision == pdDecisionPrefill || decision == pdDecisionForcedPrefill {
+		metricDecision = pdDecisionMetricPrefill
+	}
+	metrics.PDDecisions.WithLabelValues(h.typedName.Name, metricDecision).Inc()
+}
+
+// logDecision logs a single structured record of the scheduling decision of the given request:
+// the pods selected by each profile along with their scores, whether prefill ran, and why.
+// The scores given to the selected pods by each scorer are logged for the traced scorers, which
+// record them in the cycle state.
+func (h *PdProfileHandler) logDecision(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest,
+	result *types.SchedulingResult, err error) {
+	logger := log.FromContext(ctx).V(h.decisionLogVerbosity)
+	if !logger.Enabled() {
+		return
+	}
+
+	decision := pdDecisionDecodeOnly
+	if cycleState != nil {
+		if state, err := types.ReadCycleStateKey[*pdDecisionState](cycleState, pdDecisionStateKey); err == nil {
+			decision = state.decision
+		}
+	}
+	requestID := ""
+	if request != nil {
+		requestID = request.RequestId
+	}
+
+	if err != nil {
+		logger.Info("Scheduling decision", "requestId", requestID, "pdDecision", decision, "threshold", h.threshold(request),
+			"error", err.Error())
+		return
+	}
+
+	var scorerScores *ScorerScoresState
+	if cycleState != nil {
+		scorerScores, _ = types.ReadCycleStateKey[*ScorerScoresState](cycleState, ScorerScoresStateKey)
+	}
+
+	pods := make(map[string]string, len(result.ProfileResults))
+	scores := make(map[string]float64, len(result.ProfileResults))
+	podScorerScores := make(map[string]map[string]float64, len(result.ProfileResults))
+	for profileName, profileResult := range result.ProfileResults {
+		if profileResult == nil || len(profileResult.TargetPods) == 0 {
+			continue
+		}
+		podName := profileResult.TargetPods[0].GetPod().NamespacedName.String()
+		pods[profileName] = podName
+		if scoredPod, ok := profileResult.TargetPods[0].(*types.ScoredPod); ok {
+			scores[profileName] = scoredPod.Score
+		}
+		if scorerScores != nil && len(scorerScores.Scores[podName]) > 0 {
+			podScorerScores[profileName] = scorerScores.Scores[podName]
+		}
+	}
+	_, prefillRan := result.ProfileResults[h.prefillProfile]
+
+	logger.Info("Scheduling decision", "requestId", requestID, "primaryProfile", result.PrimaryProfileName,
+		"pods", pods, "scores", scores, "scorerScores", podScorerScores, "prefill", prefillRan, "pdDecision", decision, "threshold", h.threshold(request))
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
@@ -13,6 +16,10 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/metrics"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/rejection"
 )
 
 const (
@@ -22,40 +29,108 @@ const (
 	defaultDecodeProfile    = "decode"
 	defaultPrefillProfile   = "prefill"
 	defaultPrefixPluginName = prefix.PrefixCachePluginType
+
+	// ForceProfileHeaderDefault is the default name of the header forcing the PD decision of a request
+	ForceProfileHeaderDefault = "x-force-profile"
+	// ForceProfileDecode is the force profile header value forcing decode only
+	ForceProfileDecode = "decode"
+	// ForceProfilePrefill is the force profile header value forcing prefill before decode
+	ForceProfilePrefill = "prefill"
+
+	// ClassHeaderDefault is the default name of the header holding the class of a request, e.g. its QoS class
+	ClassHeaderDefault = "x-qos"
+
+	// ThresholdUnitBytes measures the threshold in prompt bytes
+	ThresholdUnitBytes = "bytes"
+	// ThresholdUnitTokens measures the threshold in estimated prompt tokens
+	ThresholdUnitTokens = "tokens"
+
+	// PrefillResultStateKey is the cycle state key of the PrefillResultState, written before running
+	// the decode profile when prefill runs first
+	PrefillResultStateKey = plugins.StateKey("pd-prefill-result")
+
+	// approximateCharsPerToken is the average number of characters per token used to approximate token counts
+	approximateCharsPerToken = 4
 )
 
 type pdProfileHandlerParameters struct {
-	Threshold        int    `json:"threshold"`
-	DecodeProfile    string `json:"decodeProfile"`
-	PrefillProfile   string `json:"prefillProfile"`
-	PrefixPluginName string `json:"prefixPluginName"`
-	HashBlockSize    int    `json:"hashBlockSize"`
+	Threshold          int    `json:"threshold"`
+	DecodeProfile      string `json:"decodeProfile"`
+	PrefillProfile     string `json:"prefillProfile"`
+	PrefixPluginName   string `json:"prefixPluginName"`
+	HashBlockSize      int    `json:"hashBlockSize"`
+	AllowForceProfile  bool   `json:"allowForceProfile"`
+	ForceProfileHeader string `json:"forceProfileHeader"`
+	ThresholdUnit      string `json:"thresholdUnit"`
+	// DecodeLoadBypassThreshold is the waiting queue size of the decode pod below which prefill is
+	// skipped regardless of the prompt length. 0 disables the bypass.
+	DecodeLoadBypassThreshold int `json:"decodeLoadBypassThreshold"`
+	// FallbackDecodeProfile is the name of the decode profile run when the decode profile finds no
+	// available decode workers. Empty disables the fallback.
+	FallbackDecodeProfile string `json:"fallbackDecodeProfile"`
+	// PrefillFirst runs the prefill profile before the decode profile, letting decode scorers
+	// consult the selected prefill pods.
+	PrefillFirst bool `json:"prefillFirst"`
+	// DecisionLogVerbosity is the log verbosity of the structured log record of each scheduling
+	// decision.
+	DecisionLogVerbosity int `json:"decisionLogVerbosity"`
+	// ClassHeader is the name of the request header holding the class of a request.
+	ClassHeader string `json:"classHeader"`
+	// ClassThresholds maps request classes to the threshold used for their requests, instead of
+	// Threshold. 0 always runs prefill for the class.
+	ClassThresholds map[string]int `json:"classThresholds"`
 }
 
 // compile-time type assertion
 var _ framework.ProfileHandler = &PdProfileHandler{}
 
 // PdProfileHandlerFactory defines the factory function for the PdProfileHandler
-func PdProfileHandlerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+func PdProfileHandlerFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
 	parameters := pdProfileHandlerParameters{
-		Threshold:        0,
-		DecodeProfile:    defaultDecodeProfile,
-		PrefillProfile:   defaultPrefillProfile,
-		PrefixPluginName: defaultPrefixPluginName,
-		HashBlockSize:    prefix.DefaultHashBlockSize,
+		Threshold:            0,
+		DecodeProfile:        defaultDecodeProfile,
+		PrefillProfile:       defaultPrefillProfile,
+		PrefixPluginName:     defaultPrefixPluginName,
+		HashBlockSize:        prefix.DefaultHashBlockSize,
+		ForceProfileHeader:   ForceProfileHeaderDefault,
+		DecisionLogVerbosity: logutil.DEBUG,
+		ClassHeader:          ClassHeaderDefault,
 	}
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse the parameters of the '%s' profile handler - %w", PdProfileHandlerType, err)
 		}
 	}
 
-	return NewPdProfileHandler(parameters.PrefillProfile, parameters.DecodeProfile, parameters.PrefixPluginName,
-		parameters.Threshold, parameters.HashBlockSize).WithName(name), nil
+	// the threshold decision reads the state of the prefix plugin, which must therefore be defined
+	thresholdSet := parameters.Threshold > 0
+	for _, threshold := range parameters.ClassThresholds {
+		thresholdSet = thresholdSet || threshold > 0
+	}
+	if thresholdSet {
+		if _, err := plugins.PluginByType[*prefix.Plugin](handle, parameters.PrefixPluginName); err != nil {
+			return nil, fmt.Errorf("invalid parameters of the '%s' profile handler - failed to find the '%s' prefix plugin referenced by 'prefixPluginName' - %w",
+				PdProfileHandlerType, parameters.PrefixPluginName, err)
+		}
+	}
+
+	handler, err := NewPdProfileHandler(parameters.PrefillProfile, parameters.DecodeProfile, parameters.PrefixPluginName,
+		parameters.Threshold, parameters.HashBlockSize).WithThresholdUnit(parameters.ThresholdUnit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' profile handler - %w", PdProfileHandlerType, err)
+	}
+	handler = handler.WithName(name).WithDecodeLoadBypassThreshold(parameters.DecodeLoadBypassThreshold).
+		WithFallbackDecodeProfile(parameters.FallbackDecodeProfile).WithPrefillFirst(parameters.PrefillFirst).
+		WithDecisionLogVerbosity(parameters.DecisionLogVerbosity).WithClassThresholds(parameters.ClassHeader, parameters.ClassThresholds)
+	if parameters.AllowForceProfile {
+		handler = handler.WithForceProfileHeader(parameters.ForceProfileHeader)
+	}
+	return handler, nil
 }
 
 // NewPdProfileHandler initializes a new PdProfileHandler and returns its pointer.
 func NewPdProfileHandler(prefillProfile string, decodeProfile string, prefixPluginName string, pdThreshold int, hashBlockSize int) *PdProfileHandler {
+	metrics.Register()
 	return &PdProfileHandler{
 		typedName:             plugins.TypedName{Type: PdProfileHandlerType},
 		prefixPluginTypedName: plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefixPluginName},
@@ -63,6 +138,8 @@ func NewPdProfileHandler(prefillProfile string, decodeProfile string, prefixPlug
 		prefillProfile:        prefillProfile,
 		pdThreshold:           pdThreshold,
 		hashBlockSize:         hashBlockSize,
+		promptLength:          byteCount,
+		decisionLogVerbosity:  logutil.DEBUG,
 	}
 }
 
@@ -74,6 +151,34 @@ type PdProfileHandler struct {
 	prefillProfile        string
 	pdThreshold           int
 	hashBlockSize         int
+	// forceProfileHeader is the name of the trusted header forcing the PD decision, empty if forcing is not allowed
+	forceProfileHeader string
+	// promptLength returns the length of a prompt in the threshold unit
+	promptLength func(prompt string) int
+	// decodeLoadBypassThreshold is the waiting queue size of the decode pod below which prefill is skipped, 0 if disabled
+	decodeLoadBypassThreshold int
+	// fallbackDecodeProfile is the decode profile run when the decode profile fails, empty if disabled
+	fallbackDecodeProfile string
+	// prefillFirst defines whether the prefill profile runs before the decode profile
+	prefillFirst bool
+	// decisionLogVerbosity is the log verbosity of the scheduling decision log record
+	decisionLogVerbosity int
+	// classHeader is the name of the request header holding the class of a request
+	classHeader string
+	// classThresholds maps request classes to their threshold, overriding pdThreshold
+	classThresholds map[string]int
+}
+
+// PrefillResultState holds the pods selected by the prefill profile, for the decode profile plugins
+// when prefill runs first.
+type PrefillResultState struct {
+	// TargetPods are the pods selected by the prefill profile
+	TargetPods []types.Pod
+}
+
+// Clone returns a copy of the state, as stored in the cycle state.
+func (s *PrefillResultState) Clone() plugins.StateData {
+	return &PrefillResultState{TargetPods: slices.Clone(s.TargetPods)}
 }
 
 // TypedName returns the typed name of the plugin.
@@ -87,10 +192,122 @@ func (h *PdProfileHandler) WithName(name string) *PdProfileHandler {
 	return h
 }
 
+// WithForceProfileHeader allows forcing the PD decision of a request with the given header, overriding
+// the threshold logic. The header value ForceProfileDecode forces decode only, and ForceProfilePrefill
+// forces running prefill. The header must only be set by trusted clients. An empty header disallows forcing.
+func (h *PdProfileHandler) WithForceProfileHeader(header string) *PdProfileHandler {
+	h.forceProfileHeader = header
+	return h
+}
+
+// WithThresholdUnit sets the unit the threshold is measured in, either ThresholdUnitBytes or ThresholdUnitTokens.
+// An empty unit means ThresholdUnitBytes. Token counts are approximated, see WithTokenCounter to count them otherwise.
+func (h *PdProfileHandler) WithThresholdUnit(unit string) (*PdProfileHandler, error) {
+	switch unit {
+	case "", ThresholdUnitBytes:
+		h.promptLength = byteCount
+	case ThresholdUnitTokens:
+		h.promptLength = approximateTokenCount
+	default:
+		return nil, fmt.Errorf("unknown threshold unit '%s', expected one of '%s' or '%s'",
+			unit, ThresholdUnitBytes, ThresholdUnitTokens)
+	}
+	return h, nil
+}
+
+// WithTokenCounter measures the threshold in tokens, counted by the given function.
+func (h *PdProfileHandler) WithTokenCounter(tokenCounter func(prompt string) int) *PdProfileHandler {
+	h.promptLength = tokenCounter
+	return h
+}
+
+// byteCount returns the number of bytes of the given prompt.
+func byteCount(prompt string) int {
+	return len(prompt)
+}
+
+// WithDecodeLoadBypassThreshold skips prefill, regardless of the prompt length, when the waiting queue
+// size of the decode pod is below the given threshold, letting a lightly loaded decode pod handle the
+// prompt itself. A non positive threshold disables the bypass.
+func (h *PdProfileHandler) WithDecodeLoadBypassThreshold(threshold int) *PdProfileHandler {
+	h.decodeLoadBypassThreshold = max(threshold, 0)
+	return h
+}
+
+// WithFallbackDecodeProfile sets a decode profile, e.g. of an overflow decode pool, run when the decode
+// profile finds no available decode workers. The request fails only if both decode profiles fail.
+// An empty profile name disables the fallback.
+func (h *PdProfileHandler) WithFallbackDecodeProfile(fallbackDecodeProfile string) *PdProfileHandler {
+	h.fallbackDecodeProfile = fallbackDecodeProfile
+	return h
+}
+
+// WithPrefillFirst runs the prefill profile before the decode profile, writing its result in the
+// cycle state as a PrefillResultState, so decode scorers can prefer decode pods close to the selected
+// prefill pod, e.g., to minimize the KV-cache transfer latency. Since the decode pod is unknown when
+// deciding whether to run prefill, the decision ignores its prefix cache hits and load: prefill runs
+// when the prompt length reaches the threshold, unless forced otherwise.
+func (h *PdProfileHandler) WithPrefillFirst(prefillFirst bool) *PdProfileHandler {
+	h.prefillFirst = prefillFirst
+	return h
+}
+
+// WithDecisionLogVerbosity sets the log verbosity of the structured record logged for the scheduling
+// decision of each request, holding the pods selected by each profile, whether prefill ran, and why.
+func (h *PdProfileHandler) WithDecisionLogVerbosity(verbosity int) *PdProfileHandler {
+	h.decisionLogVerbosity = max(verbosity, 0)
+	return h
+}
+
+// WithClassThresholds sets the thresholds of request classes, overriding the default threshold for
+// their requests, e.g. to always run prefill for premium requests, with a threshold of 0, while running
+// it for best-effort requests only for long prompts. The class of a request is read from the given header.
+// Requests without a class, or whose class has no threshold, use the default threshold.
+func (h *PdProfileHandler) WithClassThresholds(classHeader string, classThresholds map[string]int) *PdProfileHandler {
+	h.classHeader = classHeader
+	h.classThresholds = maps.Clone(classThresholds)
+	return h
+}
+
+// threshold returns the threshold of the given request, which is the threshold of its class, if any,
+// or the default threshold otherwise.
+func (h *PdProfileHandler) threshold(request *types.LLMRequest) int {
+	if h.classHeader == "" || request == nil {
+		return h.pdThreshold
+	}
+	if threshold, found := h.classThresholds[request.Headers[h.classHeader]]; found {
+		return threshold
+	}
+	return h.pdThreshold
+}
+
+// decodeResult returns the name and result of the decode profile that ran successfully, preferring
+// the decode profile over the fallback decode profile. The result is nil if none ran successfully.
+func (h *PdProfileHandler) decodeResult(profileResults map[string]*types.ProfileRunResult) (string, *types.ProfileRunResult) {
+	if result := profileResults[h.decodeProfile]; result != nil || h.fallbackDecodeProfile == "" {
+		return h.decodeProfile, result
+	}
+	return h.fallbackDecodeProfile, profileResults[h.fallbackDecodeProfile]
+}
+
+// approximateTokenCount approximates the number of tokens of the given prompt, counting a token per
+// approximateCharsPerToken characters, rounded up, of each whitespace separated word.
+func approximateTokenCount(prompt string) int {
+	tokens := 0
+	for _, word := range strings.Fields(prompt) {
+		tokens += (len([]rune(word)) + approximateCharsPerToken - 1) / approximateCharsPerToken
+	}
+	return tokens
+}
+
 // Pick selects the SchedulingProfiles to run from the list of candidate profiles, while taking into consideration the request properties and the
 // previously executed cycles along with their results.
 func (h *PdProfileHandler) Pick(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, profiles map[string]*framework.SchedulerProfile,
 	profileResults map[string]*types.ProfileRunResult) map[string]*framework.SchedulerProfile {
+	if h.prefillFirst {
+		return h.pickPrefillFirst(ctx, cycleState, request, profiles, profileResults)
+	}
+
 	if _, executed := profileResults[h.decodeProfile]; !executed {
 		// if decode profile was not executed yet, first let the scheduler run the decode profile
 		return map[string]*framework.SchedulerProfile{
@@ -99,13 +316,42 @@ func (h *PdProfileHandler) Pick(ctx context.Context, cycleState *types.CycleStat
 	}
 	// otherwise, decode was already executed.
 
-	// when a profile run fails its result value is nil. we need to check decode result before continuing to prefill
-	// check if all configured profiles have been executed, or if decode failed, no need to run more profiles.
-	if len(profiles) == len(profileResults) || profileResults[h.decodeProfile] == nil {
+	// when a profile run fails its result value is nil. if decode failed, fall back to the fallback decode profile, if any.
+	if fallback := h.pickFallbackDecode(ctx, cycleState, profiles, profileResults); fallback != nil {
+		return fallback
+	}
+
+	// we need to check decode result before continuing to prefill
+	// check if all configured profiles have been executed, prefill was executed, or if decode failed, no need to run more profiles.
+	decodeProfile, decodeResult := h.decodeResult(profileResults)
+	if _, prefillExecuted := profileResults[h.prefillProfile]; len(profiles) == len(profileResults) || prefillExecuted || decodeResult == nil {
 		return map[string]*framework.SchedulerProfile{}
 	}
 
-	if h.pdThreshold > 0 {
+	switch h.forcedProfile(request) {
+	case ForceProfileDecode:
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Decode only is forced by request header, using decode profile only")
+		h.recordDecision(cycleState, pdDecisionForcedDecode)
+		return map[string]*framework.SchedulerProfile{} // do not run prefill
+	case ForceProfilePrefill:
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Prefill is forced by request header, running prefill profile")
+		h.recordDecision(cycleState, pdDecisionForcedPrefill)
+		return map[string]*framework.SchedulerProfile{
+			h.prefillProfile: profiles[h.prefillProfile],
+		}
+	}
+
+	if h.decodeLoadBypassThreshold > 0 {
+		decodeTargetPods := decodeResult.TargetPods
+		if len(decodeTargetPods) > 0 && decodeTargetPods[0].GetMetrics().WaitingQueueSize < h.decodeLoadBypassThreshold {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Decode pod is lightly loaded, using decode profile only",
+				"waitingQueueSize", decodeTargetPods[0].GetMetrics().WaitingQueueSize)
+			h.recordDecision(cycleState, pdDecisionDecodeBypass)
+			return map[string]*framework.SchedulerProfile{} // do not run prefill
+		}
+	}
+
+	if threshold := h.threshold(request); threshold > 0 {
 		// if we're here that means decode profile ran successfully, and we have additional profile configured that didn't run yet,
 		// which means PD is enabled (otherwise, prefill profile is not configured at all and this profile handler is not used).
 		// inspect decode execution result to decide if prefill should run or not.
@@ -115,31 +361,146 @@ func (h *PdProfileHandler) Pick(ctx context.Context, cycleState *types.CycleStat
 		if err != nil {
 			log.FromContext(ctx).Error(err, "unable to read prefix state")
 		} else {
-			decodePod := profileResults[h.decodeProfile].TargetPods[0].GetPod().NamespacedName
+			decodePod := decodeResult.TargetPods[0].GetPod().NamespacedName
 			hitPrefix := max(prefixState.PrefixCacheServers[prefix.ServerID(decodePod)]-1, 0) // The first hit is always the model name
 			hitPercentagePrefix = float64(hitPrefix*h.hashBlockSize) / float64(len(request.Prompt))
 			log.FromContext(ctx).V(logutil.DEBUG).Info("Computed hit percentage for prefix cache", "hitPercentage", hitPercentagePrefix,
 				"promptLength", len(request.Prompt))
 		}
 
-		if (1.0-hitPercentagePrefix)*float64(len(request.Prompt)) < float64(h.pdThreshold) {
-			log.FromContext(ctx).Info("Non-cached suffix is smaller than threshold, using decode profile only", "hitPercentage", hitPercentagePrefix)
+		nonCachedSuffixLength := (1.0 - hitPercentagePrefix) * float64(h.promptLength(request.Prompt))
+		metrics.PDNonCachedSuffixLength.WithLabelValues(h.typedName.Name).Observe(nonCachedSuffixLength)
+		if nonCachedSuffixLength < float64(threshold) {
+			log.FromContext(ctx).Info("Non-cached suffix is smaller than threshold, using decode profile only", "hitPercentage", hitPercentagePrefix,
+				"decodeProfile", decodeProfile)
+			h.recordDecision(cycleState, pdDecisionBelowThreshold)
 			return map[string]*framework.SchedulerProfile{} // do not run prefill
 		}
 	}
 
 	// run the prefill profile
+	h.recordDecision(cycleState, pdDecisionPrefill)
 	return map[string]*framework.SchedulerProfile{
 		h.prefillProfile: profiles[h.prefillProfile],
 	}
 }
 
+// pickPrefillFirst selects the SchedulingProfiles to run when prefill runs before decode. The prefill
+// profile runs first if the request needs prefill, then the decode profile, and if it fails, the fallback
+// decode profile.
+func (h *PdProfileHandler) pickPrefillFirst(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest,
+	profiles map[string]*framework.SchedulerProfile, profileResults map[string]*types.ProfileRunResult) map[string]*framework.SchedulerProfile {
+	if _, executed := profileResults[h.decodeProfile]; !executed {
+		prefillResult, prefillExecuted := profileResults[h.prefillProfile]
+		if !prefillExecuted && h.prefillNeeded(ctx, cycleState, request) {
+			return map[string]*framework.SchedulerProfile{
+				h.prefillProfile: profiles[h.prefillProfile],
+			}
+		}
+
+		if prefillResult != nil && cycleState != nil {
+			cycleState.Write(PrefillResultStateKey, &PrefillResultState{TargetPods: prefillResult.TargetPods})
+		}
+		return map[string]*framework.SchedulerProfile{
+			h.decodeProfile: profiles[h.decodeProfile],
+		}
+	}
+
+	if fallback := h.pickFallbackDecode(ctx, cycleState, profiles, profileResults); fallback != nil {
+		return fallback
+	}
+	return map[string]*framework.SchedulerProfile{}
+}
+
+// prefillNeeded returns true if prefill should run for the given request before knowing its decode pod,
+// i.e., if it is forced, or if the prompt length reaches the threshold.
+// The decision is recorded in the given cycle state.
+func (h *PdProfileHandler) prefillNeeded(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest) bool {
+	switch h.forcedProfile(request) {
+	case ForceProfileDecode:
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Decode only is forced by request header, using decode profile only")
+		h.recordDecision(cycleState, pdDecisionForcedDecode)
+		return false
+	case ForceProfilePrefill:
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Prefill is forced by request header, running prefill profile")
+		h.recordDecision(cycleState, pdDecisionForcedPrefill)
+		return true
+	}
+
+	threshold := h.threshold(request)
+	if threshold > 0 {
+		// the decode pod is unknown, so the whole prompt is considered non-cached
+		metrics.PDNonCachedSuffixLength.WithLabelValues(h.typedName.Name).Observe(float64(h.promptLength(request.Prompt)))
+	}
+	if threshold > 0 && h.promptLength(request.Prompt) < threshold {
+		log.FromContext(ctx).Info("Prompt is shorter than threshold, using decode profile only")
+		h.recordDecision(cycleState, pdDecisionBelowThreshold)
+		return false
+	}
+	h.recordDecision(cycleState, pdDecisionPrefill)
+	return true
+}
+
+// pickFallbackDecode returns the fallback decode profile to run if the decode profile failed and the fallback
+// decode profile did not run yet, or nil otherwise. The fallback decode profile does not run for a request
+// rejected by a filter, which would be scheduled regardless of its rejection otherwise.
+func (h *PdProfileHandler) pickFallbackDecode(ctx context.Context, cycleState *types.CycleState,
+	profiles map[string]*framework.SchedulerProfile, profileResults map[string]*types.ProfileRunResult) map[string]*framework.SchedulerProfile {
+	if _, executed := profileResults[h.fallbackDecodeProfile]; profileResults[h.decodeProfile] != nil || h.fallbackDecodeProfile == "" || executed {
+		return nil
+	}
+	if reason := rejectionReason(cycleState); reason != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Request was rejected, not running the fallback decode profile", "reason", reason.Error())
+		return nil
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("No available decode workers, running the fallback decode profile",
+		"fallbackDecodeProfile", h.fallbackDecodeProfile)
+	return map[string]*framework.SchedulerProfile{
+		h.fallbackDecodeProfile: profiles[h.fallbackDecodeProfile],
+	}
+}
+
+// rejectionReason returns the reason recorded by a filter rejecting the request, or nil if it was not rejected
+func rejectionReason(cycleState *types.CycleState) error {
+	if cycleState == nil {
+		return nil
+	}
+	reason, err := types.ReadCycleStateKey[*rejection.PromptTooLongError](cycleState, rejection.PromptTooLongStateKey)
+	if err != nil {
+		return nil
+	}
+	return reason
+}
+
+// forcedProfile returns the PD decision forced by the given request, or an empty string if none is forced
+func (h *PdProfileHandler) forcedProfile(request *types.LLMRequest) string {
+	if h.forceProfileHeader == "" || request == nil {
+		return ""
+	}
+	return request.Headers[h.forceProfileHeader]
+}
+
 // ProcessResults handles the outcome of the profile runs after the selected profiles ran.
 // In case of an error in any of the profiles, the matching entry in the profileResults will contain nil, to indicate there was
-// an error while running the profile.
-func (h *PdProfileHandler) ProcessResults(_ context.Context, _ *types.CycleState, _ *types.LLMRequest,
+// an error while running the profile. If the request was rejected by a filter recording its reason, the reason is returned.
+// The primary profile is the decode profile, or the fallback decode profile if the decode profile failed.
+// The scheduling decision is logged as a single structured record.
+func (h *PdProfileHandler) ProcessResults(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest,
+	profileResults map[string]*types.ProfileRunResult) (*types.SchedulingResult, error) {
+	result, err := h.processResults(cycleState, profileResults)
+	h.logDecision(ctx, cycleState, request, result, err)
+	return result, err
+}
+
+// processResults returns the scheduling result of the given profile results.
+func (h *PdProfileHandler) processResults(cycleState *types.CycleState,
 	profileResults map[string]*types.ProfileRunResult) (*types.SchedulingResult, error) {
-	if profileResults[h.decodeProfile] == nil { // if decode profile failed to run, we should fail
+	decodeProfile, decodeResult := h.decodeResult(profileResults)
+	if decodeResult == nil { // if both decode profiles failed to run, we should fail
+		if reason := rejectionReason(cycleState); reason != nil {
+			return nil, fmt.Errorf("failed to find available decode workers - %w", reason)
+		}
 		return nil, errors.New("failed to find available decode workers")
 	}
 	// otherwise, decode ran successfully
@@ -147,16 +508,19 @@ func (h *PdProfileHandler) ProcessResults(_ context.Context, _ *types.CycleState
 	// if both prefill and decode ran successfully
 	if prefillRunResult, exists := profileResults[h.prefillProfile]; exists && prefillRunResult != nil {
 		return &types.SchedulingResult{
-			PrimaryProfileName: h.decodeProfile,
-			ProfileResults:     profileResults,
+			PrimaryProfileName: decodeProfile,
+			ProfileResults: map[string]*types.ProfileRunResult{
+				decodeProfile:    decodeResult,
+				h.prefillProfile: prefillRunResult,
+			},
 		}, nil
 	}
 
 	// otherwise, decode ran successfully and prefill failed. filter out prefill from the returned results.
 	return &types.SchedulingResult{
-		PrimaryProfileName: h.decodeProfile,
+		PrimaryProfileName: decodeProfile,
 		ProfileResults: map[string]*types.ProfileRunResult{
-			h.decodeProfile: profileResults[h.decodeProfile], // return decode only
+			decodeProfile: decodeResult, // return decode only
 		},
 	}, nil
 }
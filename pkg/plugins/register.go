@@ -2,6 +2,7 @@ package plugins
 
 import (
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/picker"
 	prerequest "github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/pre-request"
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/profile"
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
@@ -14,10 +15,44 @@ func RegisterAllPlugins() {
 	plugins.Register(filter.ByLabelSelectorType, filter.ByLabelSelectorFactory)
 	plugins.Register(filter.DecodeRoleType, filter.DecodeRoleFactory)
 	plugins.Register(filter.PrefillRoleType, filter.PrefillRoleFactory)
+	plugins.Register(filter.MaxContextType, filter.MaxContextFactory)
+	plugins.Register(filter.MaxPromptLengthType, filter.MaxPromptLengthFactory)
+	plugins.Register(filter.TenantQuotaType, filter.TenantQuotaFactory)
+	plugins.Register(filter.RegionAffinityType, filter.RegionAffinityFactory)
+	plugins.Register(filter.KVCacheHeadroomType, filter.KVCacheHeadroomFactory)
+	plugins.Register(filter.MetricsFreshnessType, filter.MetricsFreshnessFactory)
+	plugins.Register(filter.ModelAliasType, filter.ModelAliasFactory)
+	plugins.Register(filter.PinPodType, filter.PinPodFactory)
+	plugins.Register(filter.CircuitBreakerType, filter.CircuitBreakerFactory)
+	plugins.Register(picker.PowerOfTwoChoicesType, picker.PowerOfTwoChoicesFactory)
+	plugins.Register(picker.StableMaxScoreType, picker.StableMaxScoreFactory)
+	plugins.Register(picker.SeedableWeightedRandomType, picker.SeedableWeightedRandomFactory)
 	plugins.Register(prerequest.PrefillHeaderHandlerType, prerequest.PrefillHeaderHandlerFactory)
+	plugins.Register(prerequest.DecodeHeaderHandlerType, prerequest.DecodeHeaderHandlerFactory)
 	plugins.Register(profile.PdProfileHandlerType, profile.PdProfileHandlerFactory)
 	plugins.Register(scorer.PrecisePrefixCachePluginType, scorer.PrecisePrefixCachePluginFactory)
 	plugins.Register(scorer.LoadAwareType, scorer.LoadAwareFactory)
+	plugins.Register(scorer.LoadAndCacheAwareType, scorer.LoadAndCacheAwareFactory)
 	plugins.Register(scorer.SessionAffinityType, scorer.SessionAffinityFactory)
 	plugins.Register(scorer.ActiveRequestType, scorer.ActiveRequestFactory)
+	plugins.Register(scorer.FallbackType, scorer.FallbackFactory)
+	plugins.Register(scorer.NUMAAlignmentType, scorer.NUMAAlignmentFactory)
+	plugins.Register(scorer.GPUHeadroomType, scorer.GPUHeadroomFactory)
+	plugins.Register(scorer.TopologyLocalityType, scorer.TopologyLocalityFactory)
+	plugins.Register(scorer.PrefillLocalityType, scorer.PrefillLocalityFactory)
+	plugins.Register(scorer.WarmupType, scorer.WarmupFactory)
+	plugins.Register(scorer.PromptClusterType, scorer.PromptClusterFactory)
+	plugins.Register(scorer.AdaptiveBalanceType, scorer.AdaptiveBalanceFactory)
+	plugins.Register(scorer.GoodputType, scorer.GoodputFactory)
+	plugins.Register(scorer.ColdRequestType, scorer.ColdRequestFactory)
+	plugins.Register(scorer.SLOComplianceType, scorer.SLOComplianceFactory)
+	plugins.Register(scorer.ExpectedTTFTType, scorer.ExpectedTTFTFactory)
+	plugins.Register(scorer.EmbeddingSimilarityType, scorer.EmbeddingSimilarityFactory)
+	plugins.Register(scorer.AdmissionLatencyType, scorer.AdmissionLatencyFactory)
+	plugins.Register(scorer.SystemPromptAffinityType, scorer.SystemPromptAffinityFactory)
+	plugins.Register(scorer.CachedType, scorer.CachedFactory)
+	plugins.Register(scorer.HybridPrefixCacheType, scorer.HybridPrefixCacheFactory)
+	plugins.Register(scorer.ScoringTraceType, scorer.ScoringTraceFactory)
+	plugins.Register(scorer.TracedType, scorer.TracedFactory)
+	plugins.Register(scorer.ScoreFloorType, scorer.ScoreFloorFactory)
 }
@@ -0,0 +1,37 @@
+// Package rejection holds the reasons recorded in the scheduling cycle state by plugins rejecting a
+// request, so other plugins, e.g. profile handlers, can surface them without depending on the
+// rejecting plugins.
+package rejection
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
+
+const (
+	// PromptTooLongStateKey is the cycle state key of the PromptTooLongError recorded when a request is rejected
+	// for its prompt length
+	PromptTooLongStateKey = plugins.StateKey("prompt-too-long")
+)
+
+// PromptTooLongError is the reason recorded for a request rejected since its prompt is too long.
+type PromptTooLongError struct {
+	// Length is the length of the request's prompt
+	Length int
+	// MaxLength is the maximal prompt length
+	MaxLength int
+	// Unit is the unit of the lengths
+	Unit string
+}
+
+// Error returns a description of the rejection.
+func (e *PromptTooLongError) Error() string {
+	return fmt.Sprintf("prompt length of %d %s exceeds the maximal prompt length of %d %s", e.Length, e.Unit, e.MaxLength, e.Unit)
+}
+
+// Clone returns a copy of the error, as stored in the cycle state.
+func (e *PromptTooLongError) Clone() plugins.StateData {
+	clone := *e
+	return &clone
+}
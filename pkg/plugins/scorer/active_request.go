@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jellydator/ttlcache/v3"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
@@ -15,6 +18,9 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/metrics"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
@@ -34,12 +40,33 @@ type ActiveRequestParameters struct {
 	// be timed out and dropped.
 	// This field accepts duration strings like "30s", "1m", "2h".
 	RequestTimeout string `json:"requestTimeout"`
+
+	// StreamingTimeout defines the timeout for streaming requests, counted
+	// from the start of their response stream. Since the response hook runs
+	// once, when the stream starts, streaming requests are kept "in-flight"
+	// until this timeout expires, rather than removed by the hook.
+	// When unset, streaming requests are removed by the hook like any other request.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	StreamingTimeout string `json:"streamingTimeout"`
+
+	// WeightByPromptLength defines whether requests are weighted by their
+	// prompt length instead of being counted equally. When set, the summed
+	// prompt length of the in-flight requests is tracked per pod.
+	WeightByPromptLength bool `json:"weightByPromptLength"`
+
+	// MaxTrackedRequests bounds the number of tracked request entries, one
+	// per request and target pod. Once reached, the least recently tracked
+	// entries are evicted. 0 means unbounded.
+	MaxTrackedRequests int `json:"maxTrackedRequests"`
 }
 
 // requestEntry represents a single request in the cache
 type requestEntry struct {
 	PodName   string
 	RequestID string
+	Weight    int
+	// Streaming is set once the response of the request is detected as a stream
+	Streaming bool
 }
 
 // String returns a string representation of the request entry.
@@ -54,12 +81,16 @@ var _ framework.Scorer = &ActiveRequest{}
 func ActiveRequestFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
 	parameters := ActiveRequestParameters{}
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", ActiveRequestType, err)
 		}
 	}
 
-	return NewActiveRequest(handle.Context(), &parameters).WithName(name), nil
+	scorer := NewActiveRequest(handle.Context(), &parameters).WithName(name)
+	// the context of the handle is done when the EPP shuts down
+	go scorer.drainOnDone(handle.Context())
+
+	return scorer, nil
 }
 
 // NewActiveRequest creates a new ActiveRequest scorer.
@@ -77,33 +108,80 @@ func NewActiveRequest(ctx context.Context, params *ActiveRequestParameters) *Act
 		}
 	}
 
+	streamingTimeout := time.Duration(0)
+	if params != nil && params.StreamingTimeout != "" {
+		paramsStreamingTimeout, err := time.ParseDuration(params.StreamingTimeout)
+		if err != nil || paramsStreamingTimeout <= 0 {
+			logger.Error(err, "Invalid streaming timeout duration, streaming requests are not extended")
+		} else {
+			streamingTimeout = paramsStreamingTimeout
+			logger.Info("Using streaming timeout", "streamingTimeout", streamingTimeout)
+		}
+	}
+
 	// cache for individual requests with their own TTL
-	requestCache := ttlcache.New[string, *requestEntry](
+	cacheOptions := []ttlcache.Option[string, *requestEntry]{
 		ttlcache.WithTTL[string, *requestEntry](requestTimeout),
 		ttlcache.WithDisableTouchOnHit[string, *requestEntry](),
+	}
+	if params != nil && params.MaxTrackedRequests > 0 {
+		cacheOptions = append(cacheOptions, ttlcache.WithCapacity[string, *requestEntry](uint64(params.MaxTrackedRequests)))
+	}
+	requestCache := ttlcache.New[string, *requestEntry](cacheOptions...)
+	generatedIDs := ttlcache.New[*types.LLMRequest, string](
+		ttlcache.WithTTL[*types.LLMRequest, string](requestTimeout),
+		ttlcache.WithDisableTouchOnHit[*types.LLMRequest, string](),
 	)
 
 	scorer := &ActiveRequest{
-		typedName:    plugins.TypedName{Type: ActiveRequestType},
-		requestCache: requestCache,
-		podCounts:    make(map[string]int),
-		mutex:        &sync.RWMutex{},
-	}
-	// callback to decrement count when requests expire
-	// most requests will be removed in PostResponse, but this ensures
-	// that we don't leak pod counts if PostResponse is not called
+		typedName:            plugins.TypedName{Type: ActiveRequestType},
+		requestCache:         requestCache,
+		generatedIDs:         generatedIDs,
+		podCounts:            make(map[string]int),
+		requestKeys:          make(map[string]map[string]struct{}),
+		mutex:                &sync.RWMutex{},
+		weightByPromptLength: params != nil && params.WeightByPromptLength,
+		streamingTimeout:     streamingTimeout,
+	}
+	// callback to decrement count when requests are evicted, e.g., expire or
+	// exceed the capacity. most requests will be removed in PostResponse, but
+	// this ensures that we don't leak pod counts if PostResponse is not called.
+	// entries deleted explicitly are removed by PostResponse, which already
+	// decremented their count
 	requestCache.OnEviction(func(_ context.Context, reason ttlcache.EvictionReason,
 		item *ttlcache.Item[string, *requestEntry]) {
-		if reason == ttlcache.EvictionReasonExpired {
-			scorer.decrementPodCount(item.Value().PodName)
+		if reason == ttlcache.EvictionReasonDeleted {
+			return
+		}
+		scorer.decrementPodCount(item.Value().PodName, item.Value().Weight)
+		scorer.unindexRequestKey(item.Value().RequestID, item.Key())
+		// streaming requests are expected to end by expiration
+		if reason != ttlcache.EvictionReasonExpired || !item.Value().Streaming {
+			metrics.ActiveRequestEvictions.WithLabelValues(scorer.typedName.Name, evictionReasonLabel(reason)).Inc()
 		}
 	})
+	metrics.Register()
 
 	go cleanCachePeriodically(ctx, requestCache, requestTimeout)
+	go cleanCachePeriodically(ctx, generatedIDs, requestTimeout)
 
 	return scorer
 }
 
+// evictionReasonLabel returns the metric label value of the given eviction reason.
+func evictionReasonLabel(reason ttlcache.EvictionReason) string {
+	switch reason {
+	case ttlcache.EvictionReasonExpired:
+		return "expired"
+	case ttlcache.EvictionReasonCapacityReached:
+		return "capacity_reached"
+	case ttlcache.EvictionReasonMaxCostExceeded:
+		return "max_cost_exceeded"
+	default:
+		return "deleted"
+	}
+}
+
 // ActiveRequest keeps track of individual requests being served
 // per pod.
 type ActiveRequest struct {
@@ -112,9 +190,22 @@ type ActiveRequest struct {
 	// requestCache stores individual request entries with unique composite keys (podName.requestID)
 	requestCache *ttlcache.Cache[string, *requestEntry]
 
-	// podCounts maintains fast lookup for request counts per pod
+	// generatedIDs holds the IDs generated for the in-flight requests without a request ID.
+	generatedIDs *ttlcache.Cache[*types.LLMRequest, string]
+
+	// podCounts maintains fast lookup for request counts per pod.
+	// When weighting by prompt length, it holds the summed prompt length per pod.
 	podCounts map[string]int
-	mutex     *sync.RWMutex
+	// requestKeys indexes the request cache keys by request ID, for O(1) cleanup
+	// of all the entries of a request
+	requestKeys map[string]map[string]struct{}
+	mutex       *sync.RWMutex
+
+	// weightByPromptLength defines whether requests are weighted by their prompt length
+	weightByPromptLength bool
+	// streamingTimeout defines the timeout of streaming requests from the start of their stream,
+	// 0 if streaming requests are not extended
+	streamingTimeout time.Duration
 }
 
 // TypedName returns the typed name of the plugin.
@@ -129,6 +220,7 @@ func (s *ActiveRequest) WithName(name string) *ActiveRequest {
 }
 
 // Score scores the given pods based on the number of active requests
+// (or their summed prompt length, when weighting by prompt length)
 // being served by each pod. The score is normalized to a range of 0-1.
 func (s *ActiveRequest) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest,
 	pods []types.Pod) map[types.Pod]float64 {
@@ -162,76 +254,228 @@ func (s *ActiveRequest) Score(ctx context.Context, _ *types.CycleState, _ *types
 }
 
 // PreRequest is called before a request is sent to the target pod.
-// It creates a new request entry in the cache with its own TTL and
-// increments the pod count for fast lookup.
+// It creates a new request entry in the cache with its own TTL for each
+// target pod, including fallback pods, and increments the pod count for
+// fast lookup. A pod selected by several profiles of the same request is
+// counted once.
 func (s *ActiveRequest) PreRequest(ctx context.Context, request *types.LLMRequest,
 	schedulingResult *types.SchedulingResult, _ int) {
 	debugLogger := log.FromContext(ctx).V(logutil.DEBUG)
+	requestID := s.assignRequestID(request)
 
 	for _, profileResult := range schedulingResult.ProfileResults { // schedulingResult guaranteed not to be nil
 		if profileResult == nil || profileResult.TargetPods == nil || len(profileResult.TargetPods) == 0 {
 			continue
 		}
 
-		// create request entry for first pod only. TODO: support fallback pods
-		entry := &requestEntry{
-			PodName:   profileResult.TargetPods[0].GetPod().NamespacedName.String(),
-			RequestID: request.RequestId,
-		}
+		for _, targetPod := range profileResult.TargetPods {
+			entry := &requestEntry{
+				PodName:   targetPod.GetPod().NamespacedName.String(),
+				RequestID: requestID,
+				Weight:    s.requestWeight(request),
+			}
 
-		// add to request cache with TTL
-		s.requestCache.Set(entry.String(), entry, 0) // Use default TTL
-		s.incrementPodCount(entry.PodName)
+			// a pod selected by multiple profiles (e.g., a 'both'-role pod serving prefill and decode)
+			// serves a single in-flight request, count it once per request
+			if s.requestCache.Has(entry.String()) {
+				debugLogger.Info("Request already counted for pod", "requestEntry", entry.String())
+				continue
+			}
 
-		debugLogger.Info("Added request to cache", "requestEntry", entry.String())
+			// add to request cache with TTL
+			s.requestCache.Set(entry.String(), entry, 0) // Use default TTL
+			s.indexRequestKey(entry.RequestID, entry.String())
+			s.incrementPodCount(entry.PodName, entry.Weight)
+
+			debugLogger.Info("Added request to cache", "requestEntry", entry.String())
+		}
 	}
 }
 
 // PostResponse is called after a response is sent to the client.
-// It removes the specific request entry from the cache and decrements
-// the pod count.
+// It removes the request entry of the pod that served the request, as well
+// as the stale entries of the request on other (e.g., fallback) pods, from
+// the cache and decrements the pod counts.
+//
+// Since the hook is called once, when the response starts, a streaming
+// response is still being served. When a streaming timeout is set, the entry
+// of the pod serving the stream is kept, and its TTL is extended to the
+// streaming timeout, decrementing the pod count once it expires.
 func (s *ActiveRequest) PostResponse(ctx context.Context, request *types.LLMRequest,
-	_ *requestcontrol.Response, targetPod *backend.Pod) {
+	response *requestcontrol.Response, targetPod *backend.Pod) {
 	debugLogger := log.FromContext(ctx).V(logutil.DEBUG).WithName("ActiveRequest.PostResponse")
 	if targetPod == nil {
 		debugLogger.Info("Skipping PostResponse because targetPod is nil")
 		return
 	}
 
-	entry := requestEntry{targetPod.NamespacedName.String(), request.RequestId}
+	requestID := s.requestIDOf(request)
+	entry := requestEntry{PodName: targetPod.NamespacedName.String(), RequestID: requestID}
+	servedFound := false
+	streaming := s.streamingTimeout > 0 && streamingResponse(response)
+
+	for key := range s.takeRequestKeys(requestID) {
+		if streaming && key == entry.String() {
+			if item := s.requestCache.Get(key); item != nil {
+				streamEntry := *item.Value()
+				streamEntry.Streaming = true
+				s.requestCache.Set(key, &streamEntry, s.streamingTimeout)
+				s.indexRequestKey(streamEntry.RequestID, key)
+				servedFound = true
+				debugLogger.Info("Extended streaming request in cache", "requestEntry", key, "timeout", s.streamingTimeout)
+				continue
+			}
+		}
+
+		item, found := s.requestCache.GetAndDelete(key)
+		if !found {
+			continue
+		}
+		s.decrementPodCount(item.Value().PodName, item.Value().Weight)
+		if key == entry.String() {
+			servedFound = true
+			debugLogger.Info("Removed request from cache", "requestEntry", key)
+		} else {
+			debugLogger.Info("Removed stale request entry from cache", "requestEntry", key)
+		}
+	}
 
-	if _, found := s.requestCache.GetAndDelete(entry.String()); found {
-		s.decrementPodCount(entry.PodName)
-		debugLogger.Info("Removed request from cache", "requestEntry", entry.String())
-	} else {
+	if !servedFound {
 		debugLogger.Info("Request not found in cache", "requestEntry", entry.String())
 	}
 }
 
-// incrementPodCount increments the request count for a pod.
-func (s *ActiveRequest) incrementPodCount(podName string) {
+// assignRequestID returns the ID of the given request. Requests without an ID, which would otherwise
+// collide in the request cache, are assigned a generated ID. The ID is kept by the scorer, keyed by
+// the request, for its PostResponse call, which receives the same request. It is not stored in the
+// request headers, which are forwarded to the model server and shared by all scorer instances.
+func (s *ActiveRequest) assignRequestID(request *types.LLMRequest) string {
+	if request.RequestId != "" {
+		return request.RequestId
+	}
+	if item := s.generatedIDs.Get(request); item != nil {
+		return item.Value()
+	}
+	requestID := uuid.NewString()
+	s.generatedIDs.Set(request, requestID, ttlcache.DefaultTTL)
+	return requestID
+}
+
+// requestIDOf returns the ID of the given request, or the ID assigned to it by assignRequestID.
+func (s *ActiveRequest) requestIDOf(request *types.LLMRequest) string {
+	if request.RequestId != "" {
+		return request.RequestId
+	}
+	if item, found := s.generatedIDs.GetAndDelete(request); found {
+		return item.Value()
+	}
+	return ""
+}
+
+// streamingResponse returns true if the given response is streamed, as detected by its content type.
+// The streaming flags of the response are not set when the response hook is called.
+func streamingResponse(response *requestcontrol.Response) bool {
+	if response == nil {
+		return false
+	}
+	return strings.Contains(response.Headers["content-type"], "text/event-stream")
+}
+
+// indexRequestKey adds the given request cache key to the index of the request.
+func (s *ActiveRequest) indexRequestKey(requestID string, key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys, exists := s.requestKeys[requestID]
+	if !exists {
+		keys = make(map[string]struct{})
+		s.requestKeys[requestID] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+// unindexRequestKey removes the given request cache key from the index of the request.
+func (s *ActiveRequest) unindexRequestKey(requestID string, key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if keys, exists := s.requestKeys[requestID]; exists {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(s.requestKeys, requestID)
+		}
+	}
+}
+
+// takeRequestKeys removes the index of the given request and returns its request cache keys.
+func (s *ActiveRequest) takeRequestKeys(requestID string) map[string]struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := s.requestKeys[requestID]
+	delete(s.requestKeys, requestID)
+	return keys
+}
+
+// requestWeight returns the weight of the given request, which is its prompt
+// length when weighting by prompt length, and 1 otherwise.
+func (s *ActiveRequest) requestWeight(request *types.LLMRequest) int {
+	if s.weightByPromptLength {
+		return max(len(request.Prompt), 1)
+	}
+	return 1
+}
+
+// incrementPodCount adds the given request weight to the count of a pod.
+func (s *ActiveRequest) incrementPodCount(podName string, weight int) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.podCounts[podName]++
+	s.podCounts[podName] += weight
+	metrics.ActiveRequestPodRequests.WithLabelValues(s.typedName.Name, podName).Set(float64(s.podCounts[podName]))
 }
 
-// decrementPodCount decrements the request count for a pod and removes
-// the entry if count reaches zero.
-func (s *ActiveRequest) decrementPodCount(podName string) {
+// decrementPodCount subtracts the given request weight from the count of
+// a pod and removes the entry if count reaches zero.
+func (s *ActiveRequest) decrementPodCount(podName string, weight int) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	if count, exists := s.podCounts[podName]; exists {
-		if count <= 1 {
+		if count <= weight {
 			delete(s.podCounts, podName)
+			metrics.ActiveRequestPodRequests.DeleteLabelValues(s.typedName.Name, podName)
 		} else {
-			s.podCounts[podName] = count - 1
+			s.podCounts[podName] = count - weight
+			metrics.ActiveRequestPodRequests.WithLabelValues(s.typedName.Name, podName).Set(float64(count - weight))
 		}
 	}
 }
 
-func cleanCachePeriodically(ctx context.Context, cache *ttlcache.Cache[string, *requestEntry], requestTimeout time.Duration) {
+// Drain returns a snapshot of the in-flight load tracked per pod, as scored, i.e. the summed prompt
+// lengths when weighting by prompt length, and logs it. It is called when the EPP shuts down, to
+// report the requests that were still in-flight. Drain does not stop the tracking of requests.
+func (s *ActiveRequest) Drain(ctx context.Context) map[string]int {
+	s.mutex.RLock()
+	inFlight := maps.Clone(s.podCounts)
+	s.mutex.RUnlock()
+
+	total := 0
+	for _, count := range inFlight {
+		total += count
+	}
+
+	log.FromContext(ctx).Info("Draining in-flight requests", "scorer", s.typedName.Name, "total", total, "pods", inFlight)
+	return inFlight
+}
+
+// drainOnDone drains the scorer once the given context is done.
+func (s *ActiveRequest) drainOnDone(ctx context.Context) {
+	<-ctx.Done()
+	s.Drain(ctx)
+}
+
+func cleanCachePeriodically[K comparable, V any](ctx context.Context, cache *ttlcache.Cache[K, V], requestTimeout time.Duration) {
 	ticker := time.NewTicker(requestTimeout)
 	defer ticker.Stop()
 
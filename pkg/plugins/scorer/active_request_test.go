@@ -2,13 +2,20 @@ package scorer
 
 import (
 	"context"
+	"maps"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/google/go-cmp/cmp"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 )
@@ -271,6 +278,71 @@ func TestActiveRequestScorer_TTLExpiration(t *testing.T) {
 	if exists {
 		t.Errorf("Pod should be removed from podCounts after TTL expiration")
 	}
+
+	// Check that the eviction is counted
+	if evictions := gatherMetricValue(t, "inference_extension_active_request_scorer_evictions_total",
+		map[string]string{"plugin_name": "", "reason": "expired"}); evictions < 1 {
+		t.Errorf("Expected at least one eviction to be counted, got %v", evictions)
+	}
+}
+
+func TestActiveRequestScorer_CapacityEviction(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, &ActiveRequestParameters{MaxTrackedRequests: 2}).WithName("capacity-test")
+
+	podA := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+	}
+	podB := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+	}
+	schedule := func(requestID string, pod types.Pod) *types.LLMRequest {
+		request := &types.LLMRequest{RequestId: requestID}
+		scorer.PreRequest(ctx, request, &types.SchedulingResult{
+			ProfileResults: map[string]*types.ProfileRunResult{"test-profile": {TargetPods: []types.Pod{pod}}},
+		}, 0)
+		return request
+	}
+	podCounts := func() map[string]int {
+		scorer.mutex.RLock()
+		defer scorer.mutex.RUnlock()
+		return maps.Clone(scorer.podCounts)
+	}
+
+	request1 := schedule("request-1", podA)
+	schedule("request-2", podA)
+	request3 := schedule("request-3", podB) // evicts request-1
+
+	// evictions are handled asynchronously
+	want := map[string]int{"default/pod-a": 1, "default/pod-b": 1}
+	for deadline := time.Now().Add(time.Second); !cmp.Equal(want, podCounts()) && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if diff := cmp.Diff(want, podCounts()); diff != "" {
+		t.Fatalf("Expected the evicted request to no longer be counted (-want +got): %v", diff)
+	}
+	capacityLabels := map[string]string{"plugin_name": "capacity-test", "reason": "capacity_reached"}
+	if evictions := gatherMetricValue(t, "inference_extension_active_request_scorer_evictions_total", capacityLabels); evictions != 1 {
+		t.Errorf("Expected one capacity eviction to be counted, got %v", evictions)
+	}
+
+	// the response of an evicted request is not decremented twice
+	scorer.PostResponse(ctx, request1, &requestcontrol.Response{}, podA.GetPod())
+	scorer.PostResponse(ctx, request3, &requestcontrol.Response{}, podB.GetPod())
+	if diff := cmp.Diff(map[string]int{"default/pod-a": 1}, podCounts()); diff != "" {
+		t.Errorf("Unexpected pod counts after the responses (-want +got): %v", diff)
+	}
+
+	// explicit removals are not counted as evictions
+	time.Sleep(50 * time.Millisecond)
+	if evictions := gatherMetricValue(t, "inference_extension_active_request_scorer_evictions_total", capacityLabels); evictions != 1 {
+		t.Errorf("Expected one capacity eviction to be counted, got %v", evictions)
+	}
+	if evictions := gatherMetricValue(t, "inference_extension_active_request_scorer_evictions_total",
+		map[string]string{"plugin_name": "capacity-test", "reason": "deleted"}); evictions != -1 {
+		t.Errorf("Expected explicit removals not to be counted, got %v", evictions)
+	}
 }
 
 func TestNewActiveRequestScorer_InvalidTimeout(t *testing.T) {
@@ -302,3 +374,514 @@ func TestActiveRequestScorer_WithName(t *testing.T) {
 		t.Errorf("Expected name %s, got %s", testName, scorer.TypedName().Name)
 	}
 }
+
+func TestActiveRequestScorer_PodSelectedByMultipleProfiles(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, nil)
+
+	bothPod := &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Name: "pod-both", Namespace: "default"},
+			Labels:         map[string]string{"llm-d.ai/role": "both"},
+		},
+	}
+	decodePod := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-decode", Namespace: "default"}},
+	}
+
+	request := &types.LLMRequest{
+		RequestId: "test-request-1",
+	}
+
+	// the 'both'-role pod is selected for prefill and decode of the same request
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"prefill": {TargetPods: []types.Pod{bothPod}},
+			"decode":  {TargetPods: []types.Pod{bothPod}},
+		},
+	}
+	scorer.PreRequest(ctx, request, schedulingResult, 0)
+
+	// another request uses the 'both'-role pod for prefill only
+	request2 := &types.LLMRequest{
+		RequestId: "test-request-2",
+	}
+	schedulingResult2 := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"prefill": {TargetPods: []types.Pod{bothPod}},
+			"decode":  {TargetPods: []types.Pod{decodePod}},
+		},
+	}
+	scorer.PreRequest(ctx, request2, schedulingResult2, 0)
+
+	scorer.mutex.RLock()
+	bothCount := scorer.podCounts["default/pod-both"]
+	decodeCount := scorer.podCounts["default/pod-decode"]
+	scorer.mutex.RUnlock()
+	if bothCount != 2 {
+		t.Errorf("Expected pod-both count to be 2, got %d", bothCount)
+	}
+	if decodeCount != 1 {
+		t.Errorf("Expected pod-decode count to be 1, got %d", decodeCount)
+	}
+
+	// completing the first request releases its combined prefill+decode load
+	scorer.PostResponse(ctx, request, &requestcontrol.Response{}, bothPod.GetPod())
+
+	scorer.mutex.RLock()
+	bothCount = scorer.podCounts["default/pod-both"]
+	scorer.mutex.RUnlock()
+	if bothCount != 1 {
+		t.Errorf("Expected pod-both count to be 1 after PostResponse, got %d", bothCount)
+	}
+}
+
+func TestActiveRequestScorer_WeightByPromptLength(t *testing.T) {
+	ctx := context.Background()
+
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	sendRequests := func(scorer *ActiveRequest) {
+		// pod-a serves a single huge request, pod-b serves three tiny requests
+		scorer.PreRequest(ctx, &types.LLMRequest{RequestId: "huge", Prompt: strings.Repeat("a", 10000)},
+			&types.SchedulingResult{ProfileResults: map[string]*types.ProfileRunResult{"default": {TargetPods: []types.Pod{podA}}}}, 0)
+		for _, id := range []string{"tiny-1", "tiny-2", "tiny-3"} {
+			scorer.PreRequest(ctx, &types.LLMRequest{RequestId: id, Prompt: strings.Repeat("b", 100)},
+				&types.SchedulingResult{ProfileResults: map[string]*types.ProfileRunResult{"default": {TargetPods: []types.Pod{podB}}}}, 0)
+		}
+	}
+
+	// by default requests are counted equally
+	scorer := NewActiveRequest(ctx, nil)
+	sendRequests(scorer)
+	got := scorer.Score(ctx, nil, nil, []types.Pod{podA, podB})
+	if got[podA] <= got[podB] {
+		t.Errorf("Expected pod-a to score better than pod-b when counting requests, got %v", got)
+	}
+
+	// when weighting by prompt length, the huge request dominates
+	scorer = NewActiveRequest(ctx, &ActiveRequestParameters{WeightByPromptLength: true})
+	sendRequests(scorer)
+	got = scorer.Score(ctx, nil, nil, []types.Pod{podA, podB})
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0.0, podB: 0.97}, got); diff != "" {
+		t.Errorf("Unexpected output (-want +got): %v", diff)
+	}
+
+	// completing the huge request releases its whole weight
+	scorer.PostResponse(ctx, &types.LLMRequest{RequestId: "huge"}, &requestcontrol.Response{}, podA.GetPod())
+	scorer.mutex.RLock()
+	_, exists := scorer.podCounts["default/pod-a"]
+	podBCount := scorer.podCounts["default/pod-b"]
+	scorer.mutex.RUnlock()
+	if exists {
+		t.Errorf("Pod should be removed from podCounts when its weight reaches 0")
+	}
+	if podBCount != 300 {
+		t.Errorf("Expected pod-b summed prompt length to be 300, got %d", podBCount)
+	}
+}
+
+func TestActiveRequestScorer_FallbackPods(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, nil)
+
+	podA := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+	}
+	podB := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+	}
+	podC := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-c", Namespace: "default"}},
+	}
+
+	request := &types.LLMRequest{
+		RequestId: "test-request-1",
+	}
+	otherRequest := &types.LLMRequest{
+		RequestId: "test-request-2",
+	}
+
+	// the profile returns pod-a with pod-b and pod-c as fallbacks
+	scorer.PreRequest(ctx, request, &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA, podB, podC}},
+		},
+	}, 0)
+	scorer.PreRequest(ctx, otherRequest, &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podB}},
+		},
+	}, 0)
+
+	for _, key := range []string{"default/pod-a.test-request-1", "default/pod-b.test-request-1", "default/pod-c.test-request-1"} {
+		if !scorer.requestCache.Has(key) {
+			t.Errorf("Expected request to be in cache with key %s", key)
+		}
+	}
+
+	// the request is retried against fallback pod-b, which serves it
+	scorer.PostResponse(ctx, request, &requestcontrol.Response{}, podB.GetPod())
+
+	for _, key := range []string{"default/pod-a.test-request-1", "default/pod-b.test-request-1", "default/pod-c.test-request-1"} {
+		if scorer.requestCache.Has(key) {
+			t.Errorf("Expected request entry %s to be removed after PostResponse", key)
+		}
+	}
+	if !scorer.requestCache.Has("default/pod-b.test-request-2") {
+		t.Errorf("Expected entries of other requests to remain in cache")
+	}
+
+	scorer.mutex.RLock()
+	wantCounts := map[string]int{"default/pod-b": 1}
+	if diff := cmp.Diff(wantCounts, scorer.podCounts); diff != "" {
+		t.Errorf("Unexpected pod counts (-want +got): %v", diff)
+	}
+	_, indexed := scorer.requestKeys["test-request-1"]
+	scorer.mutex.RUnlock()
+	if indexed {
+		t.Errorf("Expected request index to be cleaned up after PostResponse")
+	}
+}
+
+func TestActiveRequestScorer_Metrics(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, nil).WithName("metrics-test")
+
+	podA := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+	}
+	podALabels := map[string]string{"plugin_name": "metrics-test", "pod": "default/pod-a"}
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}
+
+	// creating another instance must not fail on duplicate registration
+	_ = NewActiveRequest(ctx, nil)
+
+	request1 := &types.LLMRequest{RequestId: "test-request-1"}
+	request2 := &types.LLMRequest{RequestId: "test-request-2"}
+	scorer.PreRequest(ctx, request1, schedulingResult, 0)
+	scorer.PreRequest(ctx, request2, schedulingResult, 0)
+
+	if got := gatherMetricValue(t, "inference_extension_active_request_scorer_pod_requests", podALabels); got != 2 {
+		t.Errorf("Expected gauge of pod-a to be 2, got %v", got)
+	}
+
+	scorer.PostResponse(ctx, request1, &requestcontrol.Response{}, podA.GetPod())
+
+	if got := gatherMetricValue(t, "inference_extension_active_request_scorer_pod_requests", podALabels); got != 1 {
+		t.Errorf("Expected gauge of pod-a to be 1, got %v", got)
+	}
+
+	scorer.PostResponse(ctx, request2, &requestcontrol.Response{}, podA.GetPod())
+
+	if got := gatherMetricValue(t, "inference_extension_active_request_scorer_pod_requests", podALabels); got != -1 {
+		t.Errorf("Expected gauge of pod-a to be removed, got %v", got)
+	}
+}
+
+// gatherMetricValue scrapes the metrics registry and returns the value of the metric with the
+// given name and labels, or -1 if it is not found.
+func gatherMetricValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			metricLabels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+			if !cmp.Equal(labels, metricLabels) {
+				continue
+			}
+			if metric.GetGauge() != nil {
+				return metric.GetGauge().GetValue()
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return -1
+}
+
+func TestActiveRequestScorer_Streaming(t *testing.T) {
+	ctx := context.Background()
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}
+	streamResponse := &requestcontrol.Response{Headers: map[string]string{"content-type": "text/event-stream; charset=utf-8"}}
+
+	scorer := NewActiveRequest(ctx, &ActiveRequestParameters{RequestTimeout: "1s", StreamingTimeout: "3s"})
+	podACount := func() int {
+		scorer.mutex.RLock()
+		defer scorer.mutex.RUnlock()
+		return scorer.podCounts["default/pod-a"]
+	}
+
+	streamed := &types.LLMRequest{RequestId: "streamed"}
+	unary := &types.LLMRequest{RequestId: "unary"}
+	scorer.PreRequest(ctx, streamed, schedulingResult, 0)
+	scorer.PreRequest(ctx, unary, schedulingResult, 0)
+
+	// streams detected by content type are kept, other responses are removed
+	scorer.PostResponse(ctx, streamed, streamResponse, podA.GetPod())
+	scorer.PostResponse(ctx, unary, &requestcontrol.Response{Headers: map[string]string{"content-type": "application/json"}}, podA.GetPod())
+	if count := podACount(); count != 1 {
+		t.Fatalf("Expected streaming request to be counted, got %d", count)
+	}
+
+	// streaming requests outlive the request timeout
+	time.Sleep(2 * time.Second)
+	scorer.requestCache.DeleteExpired()
+	if count := podACount(); count != 1 {
+		t.Errorf("Expected streaming request to be extended beyond the request timeout, got %d", count)
+	}
+
+	// the streaming timeout eventually cleans up the stream
+	time.Sleep(2 * time.Second)
+	scorer.requestCache.DeleteExpired()
+	if count := podACount(); count != 0 {
+		t.Errorf("Expected streaming request to expire after the streaming timeout, got %d", count)
+	}
+	scorer.mutex.RLock()
+	indexed := len(scorer.requestKeys)
+	scorer.mutex.RUnlock()
+	if indexed != 0 {
+		t.Errorf("Expected request index to be cleaned up, got %d requests", indexed)
+	}
+
+	// without a streaming timeout, streaming requests are removed by the response hook
+	unextended := NewActiveRequest(ctx, &ActiveRequestParameters{RequestTimeout: "1s"})
+	unextended.PreRequest(ctx, streamed, schedulingResult, 0)
+	unextended.PostResponse(ctx, streamed, streamResponse, podA.GetPod())
+	unextended.mutex.RLock()
+	defer unextended.mutex.RUnlock()
+	if count := unextended.podCounts["default/pod-a"]; count != 0 {
+		t.Errorf("Expected streaming request to be removed without a streaming timeout, got %d", count)
+	}
+}
+
+func TestActiveRequestScorer_EmptyRequestID(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, nil)
+
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}
+	podCount := func() int {
+		scorer.mutex.RLock()
+		defer scorer.mutex.RUnlock()
+		return scorer.podCounts["default/pod-a"]
+	}
+
+	const numRequests = 20
+	requests := make([]*types.LLMRequest, numRequests)
+	for i := range requests {
+		requests[i] = &types.LLMRequest{}
+	}
+
+	var wg sync.WaitGroup
+	for _, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scorer.PreRequest(ctx, request, schedulingResult, 0)
+		}()
+	}
+	wg.Wait()
+
+	if got := podCount(); got != numRequests {
+		t.Fatalf("Expected count to be %d, got %d", numRequests, got)
+	}
+
+	for _, request := range requests[:numRequests/2] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scorer.PostResponse(ctx, request, &requestcontrol.Response{}, podA.GetPod())
+		}()
+	}
+	wg.Wait()
+
+	if got := podCount(); got != numRequests/2 {
+		t.Errorf("Expected count to be %d, got %d", numRequests/2, got)
+	}
+}
+
+func TestActiveRequestScorer_EmptyRequestIDMultipleInstances(t *testing.T) {
+	ctx := context.Background()
+
+	first := NewActiveRequest(ctx, nil).WithName("first")
+	second := NewActiveRequest(ctx, nil).WithName("second")
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}
+	request := &types.LLMRequest{Headers: map[string]string{}}
+
+	first.PreRequest(ctx, request, schedulingResult, 0)
+	second.PreRequest(ctx, request, schedulingResult, 0)
+
+	if len(request.Headers) != 0 {
+		t.Errorf("Expected the request headers to be unchanged, got %v", request.Headers)
+	}
+
+	first.PostResponse(ctx, request, &requestcontrol.Response{}, podA.GetPod())
+	second.PostResponse(ctx, request, &requestcontrol.Response{}, podA.GetPod())
+
+	for _, scorer := range []*ActiveRequest{first, second} {
+		scorer.mutex.RLock()
+		count := scorer.podCounts["default/pod-a"]
+		scorer.mutex.RUnlock()
+		if count != 0 {
+			t.Errorf("Expected count of scorer %s to be 0, got %d", scorer.TypedName().Name, count)
+		}
+		if got := scorer.requestCache.Len(); got != 0 {
+			t.Errorf("Expected request cache of scorer %s to be empty, got %d entries", scorer.TypedName().Name, got)
+		}
+		if got := scorer.generatedIDs.Len(); got != 0 {
+			t.Errorf("Expected generated IDs of scorer %s to be empty, got %d entries", scorer.TypedName().Name, got)
+		}
+	}
+}
+
+func TestActiveRequestScorer_Drain(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, nil)
+
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	if got := scorer.Drain(ctx); len(got) != 0 {
+		t.Fatalf("Expected no in-flight requests, got %v", got)
+	}
+
+	for _, request := range []struct {
+		id  string
+		pod types.Pod
+	}{{"req-1", podA}, {"req-2", podA}, {"req-3", podB}, {"req-4", podB}} {
+		schedulingResult := &types.SchedulingResult{
+			ProfileResults: map[string]*types.ProfileRunResult{
+				"test-profile": {TargetPods: []types.Pod{request.pod}},
+			},
+		}
+		scorer.PreRequest(ctx, &types.LLMRequest{RequestId: request.id}, schedulingResult, 0)
+	}
+	scorer.PostResponse(ctx, &types.LLMRequest{RequestId: "req-4"}, &requestcontrol.Response{}, podB.GetPod())
+
+	want := map[string]int{"default/pod-a": 2, "default/pod-b": 1}
+	if diff := cmp.Diff(want, scorer.Drain(ctx)); diff != "" {
+		t.Errorf("Unexpected in-flight requests (-want +got): %s", diff)
+	}
+
+	// draining does not stop tracking requests
+	if diff := cmp.Diff(want, scorer.Drain(ctx)); diff != "" {
+		t.Errorf("Unexpected in-flight requests after drain (-want +got): %s", diff)
+	}
+}
+
+func TestActiveRequestScorer_DrainWeighted(t *testing.T) {
+	ctx := context.Background()
+
+	scorer := NewActiveRequest(ctx, &ActiveRequestParameters{WeightByPromptLength: true})
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	schedulingResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}
+	scorer.PreRequest(ctx, &types.LLMRequest{RequestId: "req-1", Prompt: "hello"}, schedulingResult, 0)
+	scorer.PreRequest(ctx, &types.LLMRequest{RequestId: "req-2", Prompt: "hello world"}, schedulingResult, 0)
+
+	if diff := cmp.Diff(map[string]int{"default/pod-a": 16}, scorer.Drain(ctx)); diff != "" {
+		t.Errorf("Unexpected in-flight load (-want +got): %s", diff)
+	}
+}
+
+func TestActiveRequestFactory_DrainOnShutdown(t *testing.T) {
+	records := make(chan string, 1)
+	logger := funcr.New(func(_, args string) {
+		if strings.Contains(args, `"msg"="Draining in-flight requests"`) {
+			records <- args
+		}
+	}, funcr.Options{})
+	ctx, cancel := context.WithCancel(log.IntoContext(context.Background(), logger))
+	defer cancel()
+
+	plugin, err := ActiveRequestFactory("drained-active-request", nil, plugins.NewEppHandle(ctx))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	scorer := plugin.(*ActiveRequest)
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	scorer.PreRequest(ctx, &types.LLMRequest{RequestId: "req-1"}, &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"test-profile": {TargetPods: []types.Pod{podA}},
+		},
+	}, 0)
+
+	cancel()
+	select {
+	case record := <-records:
+		for _, field := range []string{`"scorer"="drained-active-request"`, `"total"=1`, `"pods"={"default/pod-a"=1}`} {
+			if !strings.Contains(record, field) {
+				t.Errorf("Expected the drain record to contain %s, got %s", field, record)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the scorer to be drained once the handle context is done")
+	}
+}
+
+// createPod creates a pod in the default namespace with the given address, labels and metrics.
+func createPod(name string, address string, labels map[string]string, metrics backendmetrics.MetricsState) *types.PodMetrics {
+	return &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+			Address:        address,
+			Labels:         labels,
+		},
+		MetricsState: &metrics,
+	}
+}
@@ -0,0 +1,150 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// AdaptiveBalanceType is the type of the AdaptiveBalance scorer.
+	AdaptiveBalanceType = "adaptive-balance-scorer"
+
+	defaultLowUtilization  = 0.2
+	defaultHighUtilization = 0.8
+)
+
+type adaptiveBalanceParameters struct {
+	// PrefixScorer is the name of the scorer plugin providing prefix affinity scores.
+	PrefixScorer string `json:"prefixScorer"`
+	// LoadScorer is the name of the scorer plugin providing load balancing scores.
+	LoadScorer string `json:"loadScorer"`
+	// LowUtilization is the fleet utilization (0-1) at or below which only prefix affinity is used.
+	LowUtilization float64 `json:"lowUtilization"`
+	// HighUtilization is the fleet utilization (0-1) at or above which only load balancing is used.
+	HighUtilization float64 `json:"highUtilization"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &AdaptiveBalance{}
+
+// AdaptiveBalanceFactory defines the factory function for the AdaptiveBalance scorer.
+func AdaptiveBalanceFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := adaptiveBalanceParameters{
+		LowUtilization:  defaultLowUtilization,
+		HighUtilization: defaultHighUtilization,
+	}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", AdaptiveBalanceType, err)
+		}
+	}
+
+	prefixScorer, err := plugins.PluginByType[framework.Scorer](handle, parameters.PrefixScorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the prefix scorer of the '%s' scorer - %w", AdaptiveBalanceType, err)
+	}
+	loadScorer, err := plugins.PluginByType[framework.Scorer](handle, parameters.LoadScorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the load scorer of the '%s' scorer - %w", AdaptiveBalanceType, err)
+	}
+
+	scorer, err := NewAdaptiveBalance(prefixScorer, loadScorer, parameters.LowUtilization, parameters.HighUtilization)
+	if err != nil {
+		return nil, err
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewAdaptiveBalance creates a new AdaptiveBalance scorer.
+// prefixScorer - the scorer providing prefix affinity scores
+// loadScorer - the scorer providing load balancing scores
+// lowUtilization - the fleet utilization at or below which only prefix affinity is used
+// highUtilization - the fleet utilization at or above which only load balancing is used
+func NewAdaptiveBalance(prefixScorer framework.Scorer, loadScorer framework.Scorer,
+	lowUtilization float64, highUtilization float64) (*AdaptiveBalance, error) {
+	if lowUtilization < 0 || highUtilization > 1 || lowUtilization >= highUtilization {
+		return nil, errors.New("utilization thresholds must satisfy 0 <= lowUtilization < highUtilization <= 1")
+	}
+
+	return &AdaptiveBalance{
+		typedName:       plugins.TypedName{Type: AdaptiveBalanceType},
+		prefixScorer:    prefixScorer,
+		loadScorer:      loadScorer,
+		lowUtilization:  lowUtilization,
+		highUtilization: highUtilization,
+	}, nil
+}
+
+// AdaptiveBalance is a meta-scorer that blends a prefix affinity scorer with a load balancing
+// scorer based on the overall fleet utilization, measured as the average KV-cache usage of the
+// candidate pods. An idle fleet maximizes prefix affinity, a saturated fleet prioritizes load
+// balancing, and in between the weight shifts linearly from prefix to load.
+type AdaptiveBalance struct {
+	typedName       plugins.TypedName
+	prefixScorer    framework.Scorer
+	loadScorer      framework.Scorer
+	lowUtilization  float64
+	highUtilization float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *AdaptiveBalance) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *AdaptiveBalance) WithName(name string) *AdaptiveBalance {
+	s.typedName.Name = name
+	return s
+}
+
+// PrefixWeight returns the relative weight, in range of 0-1, given to the prefix scorer
+// for the given pods.
+func (s *AdaptiveBalance) PrefixWeight(pods []types.Pod) float64 {
+	utilization := fleetUtilization(pods)
+	switch {
+	case utilization <= s.lowUtilization:
+		return 1.0
+	case utilization >= s.highUtilization:
+		return 0.0
+	default:
+		return (s.highUtilization - utilization) / (s.highUtilization - s.lowUtilization)
+	}
+}
+
+// Score scores the given pods by blending the scores of the prefix and load scorers
+// according to the fleet utilization. Both scorers are always invoked, even when one of them
+// is weighted out, since scorers such as the prefix cache plugin record the cycle state read
+// by other plugins when scoring.
+func (s *AdaptiveBalance) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	prefixWeight := s.PrefixWeight(pods)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Balancing prefix affinity and load", "prefixWeight", prefixWeight)
+
+	prefixScores := s.prefixScorer.Score(ctx, cycleState, request, pods)
+	loadScores := s.loadScorer.Score(ctx, cycleState, request, pods)
+
+	return blendScores(pods, prefixScores, loadScores, prefixWeight)
+}
+
+// fleetUtilization returns the average KV-cache usage of the given pods.
+func fleetUtilization(pods []types.Pod) float64 {
+	if len(pods) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, pod := range pods {
+		total += pod.GetMetrics().KVCacheUsagePercent
+	}
+	return total / float64(len(pods))
+}
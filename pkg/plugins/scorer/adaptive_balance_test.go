@@ -0,0 +1,114 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestAdaptiveBalance_Score(t *testing.T) {
+	// prefix affinity prefers pod-a, load balancing prefers pod-b
+	prefixScorer := &fixedScorer{scores: map[string]float64{"pod-a": 1, "pod-b": 0}}
+	loadScorer := &fixedScorer{scores: map[string]float64{"pod-a": 0, "pod-b": 1}}
+
+	s, err := scorer.NewAdaptiveBalance(prefixScorer, loadScorer, 0.2, 0.8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		kvCacheUsage float64
+		wantScores   func(podA, podB types.Pod) map[types.Pod]float64
+	}{
+		{
+			name:         "low fleet utilization favors prefix affinity",
+			kvCacheUsage: 0.1,
+			wantScores: func(podA, podB types.Pod) map[types.Pod]float64 {
+				return map[types.Pod]float64{podA: 1, podB: 0}
+			},
+		},
+		{
+			name:         "high fleet utilization favors load balancing",
+			kvCacheUsage: 0.9,
+			wantScores: func(podA, podB types.Pod) map[types.Pod]float64 {
+				return map[types.Pod]float64{podA: 0, podB: 1}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			podA := &types.PodMetrics{
+				Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+				MetricsState: &backendmetrics.MetricsState{KVCacheUsagePercent: test.kvCacheUsage},
+			}
+			podB := &types.PodMetrics{
+				Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+				MetricsState: &backendmetrics.MetricsState{KVCacheUsagePercent: test.kvCacheUsage},
+			}
+
+			got := s.Score(context.Background(), nil, &types.LLMRequest{}, []types.Pod{podA, podB})
+			if diff := cmp.Diff(test.wantScores(podA, podB), got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestAdaptiveBalance_InvokesBothScorers(t *testing.T) {
+	prefixScorer := &fixedScorer{}
+	loadScorer := &fixedScorer{}
+
+	s, err := scorer.NewAdaptiveBalance(prefixScorer, loadScorer, 0.2, 0.8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// scorers weighted out are still invoked, as they may record the cycle state read by other plugins
+	for _, usage := range []float64{0.1, 0.9} {
+		pods := []types.Pod{&types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+			MetricsState: &backendmetrics.MetricsState{KVCacheUsagePercent: usage},
+		}}
+		s.Score(context.Background(), nil, &types.LLMRequest{}, pods)
+	}
+
+	if prefixScorer.calls != 2 || loadScorer.calls != 2 {
+		t.Errorf("Expected both scorers to be invoked twice, got prefix %d and load %d", prefixScorer.calls, loadScorer.calls)
+	}
+}
+
+func TestAdaptiveBalance_PrefixWeightShifts(t *testing.T) {
+	s, err := scorer.NewAdaptiveBalance(&fixedScorer{}, &fixedScorer{}, 0.2, 0.8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	prevWeight := 1.0
+	for _, usage := range []float64{0.3, 0.5, 0.7} {
+		pods := []types.Pod{&types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+			MetricsState: &backendmetrics.MetricsState{KVCacheUsagePercent: usage},
+		}}
+
+		weight := s.PrefixWeight(pods)
+		if weight <= 0 || weight >= prevWeight {
+			t.Errorf("Expected prefix weight to decrease with utilization %v, got %v (previous %v)", usage, weight, prevWeight)
+		}
+		prevWeight = weight
+	}
+}
+
+func TestAdaptiveBalance_InvalidConfiguration(t *testing.T) {
+	if _, err := scorer.NewAdaptiveBalance(&fixedScorer{}, &fixedScorer{}, 0.8, 0.2); err == nil {
+		t.Error("Expected error for low utilization above high utilization")
+	}
+}
@@ -0,0 +1,152 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// AdmissionLatencyType is the type of the AdmissionLatency scorer.
+	AdmissionLatencyType = "admission-latency-scorer"
+
+	defaultAdmissionLatencyThreshold = time.Second
+	defaultAdmissionLatencyWindow    = 10 * time.Second
+)
+
+// AdmissionLatencyParameters defines the parameters for the AdmissionLatency scorer.
+type AdmissionLatencyParameters struct {
+	// LatencyThreshold defines the admission latency above which pods are penalized.
+	// This field accepts duration strings like "500ms", "2s".
+	LatencyThreshold string `json:"latencyThreshold"`
+	// Window defines the sliding window over which the current admission latency is measured.
+	// This field accepts duration strings like "10s", "1m".
+	Window string `json:"window"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer            = &AdmissionLatency{}
+	_ requestcontrol.PreRequest   = &AdmissionLatency{}
+	_ requestcontrol.PostResponse = &AdmissionLatency{}
+)
+
+// AdmissionLatencyFactory defines the factory function for the AdmissionLatency scorer.
+func AdmissionLatencyFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := AdmissionLatencyParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", AdmissionLatencyType, err)
+		}
+	}
+
+	return NewAdmissionLatency(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewAdmissionLatency creates a new AdmissionLatency scorer.
+func NewAdmissionLatency(ctx context.Context, params *AdmissionLatencyParameters) *AdmissionLatency {
+	logger := log.FromContext(ctx)
+	latencyThreshold := defaultAdmissionLatencyThreshold
+	window := defaultAdmissionLatencyWindow
+
+	if params != nil && params.LatencyThreshold != "" {
+		paramsLatencyThreshold, err := time.ParseDuration(params.LatencyThreshold)
+		if err != nil || paramsLatencyThreshold <= 0 {
+			logger.Error(err, "Invalid admission latency threshold duration, using default latency threshold")
+		} else {
+			latencyThreshold = paramsLatencyThreshold
+		}
+	}
+	if params != nil && params.Window != "" {
+		paramsWindow, err := time.ParseDuration(params.Window)
+		if err != nil || paramsWindow <= 0 {
+			logger.Error(err, "Invalid admission latency window duration, using default window")
+		} else {
+			window = paramsWindow
+		}
+	}
+
+	return &AdmissionLatency{
+		typedName:        plugins.TypedName{Type: AdmissionLatencyType},
+		latencyThreshold: latencyThreshold,
+		tracker:          newLatencyTracker(window, defaultRequestTimeout),
+	}
+}
+
+// AdmissionLatency is a scorer that penalizes pods currently slow to admit requests, e.g. pods
+// that momentarily paused admission, even if their queue is short. The admission latency of each
+// request is measured from sending it to the pod until receiving its response headers. A pod's
+// current admission latency is the highest of the average latency of its requests within a short
+// window, and the time its oldest request is already waiting for a response.
+type AdmissionLatency struct {
+	typedName        plugins.TypedName
+	latencyThreshold time.Duration
+	tracker          *latencyTracker
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *AdmissionLatency) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *AdmissionLatency) WithName(name string) *AdmissionLatency {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods in range of 0-1. Pods whose current admission latency is within the
+// latency threshold are scored with 1, slower pods by the ratio of the threshold to their latency.
+func (s *AdmissionLatency) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	now := time.Now()
+	oldestPending := s.tracker.oldestPending()
+
+	for _, pod := range pods {
+		podName := pod.GetPod().NamespacedName.String()
+
+		var admissionLatency time.Duration
+		if latencies := s.tracker.latencies(podName); len(latencies) > 0 {
+			var total time.Duration
+			for _, latency := range latencies {
+				total += latency
+			}
+			admissionLatency = total / time.Duration(len(latencies))
+		}
+		if sent, found := oldestPending[podName]; found {
+			admissionLatency = max(admissionLatency, now.Sub(sent))
+		}
+
+		if admissionLatency <= s.latencyThreshold {
+			scoredPods[pod] = 1.0
+		} else {
+			scoredPods[pod] = float64(s.latencyThreshold) / float64(admissionLatency)
+		}
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods", "scores", scoredPods)
+	return scoredPods
+}
+
+// PreRequest records the time the request is sent to its target pod.
+func (s *AdmissionLatency) PreRequest(_ context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	s.tracker.requestSent(request, schedulingResult)
+}
+
+// PostResponse records the admission latency of the request on the pod that served it.
+func (s *AdmissionLatency) PostResponse(ctx context.Context, request *types.LLMRequest, _ *requestcontrol.Response, targetPod *backend.Pod) {
+	if latency, found := s.tracker.responseReceived(request, targetPod); found {
+		log.FromContext(ctx).V(logutil.TRACE).Info("Recorded admission latency", "pod", targetPod.NamespacedName, "latency", latency)
+	}
+}
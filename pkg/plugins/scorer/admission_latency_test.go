@@ -0,0 +1,69 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestAdmissionLatency_Score(t *testing.T) {
+	ctx := context.Background()
+
+	pausedPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "paused", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	slowPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "slow", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	fastPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "fast", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	newPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "new", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{pausedPod, slowPod, fastPod, newPod}
+
+	s := scorer.NewAdmissionLatency(ctx, &scorer.AdmissionLatencyParameters{LatencyThreshold: "20ms", Window: "300ms"})
+
+	// the paused pod does not admit its request, the slow pod admits its request after ~60ms
+	pausedRequest := &types.LLMRequest{RequestId: "paused"}
+	slowRequest := &types.LLMRequest{RequestId: "slow"}
+	s.PreRequest(ctx, pausedRequest, primaryResult(pausedPod), 0)
+	s.PreRequest(ctx, slowRequest, primaryResult(slowPod), 0)
+	fastRequest := &types.LLMRequest{RequestId: "fast"}
+	s.PreRequest(ctx, fastRequest, primaryResult(fastPod), 0)
+	s.PostResponse(ctx, fastRequest, &requestcontrol.Response{}, fastPod.GetPod())
+	time.Sleep(60 * time.Millisecond)
+	s.PostResponse(ctx, slowRequest, &requestcontrol.Response{}, slowPod.GetPod())
+	time.Sleep(40 * time.Millisecond)
+
+	t.Run("pods slow to admit are penalized", func(t *testing.T) {
+		got := s.Score(ctx, nil, nil, pods)
+		assert.Equal(t, 1.0, got[fastPod])
+		assert.Equal(t, 1.0, got[newPod])
+		assert.Less(t, got[slowPod], 1.0)
+		// the paused pod is waiting on its request for ~100ms
+		assert.Less(t, got[pausedPod], got[slowPod])
+	})
+
+	t.Run("pods recover once admission resumes", func(t *testing.T) {
+		s.PostResponse(ctx, pausedRequest, &requestcontrol.Response{}, pausedPod.GetPod())
+		time.Sleep(300 * time.Millisecond)
+
+		got := s.Score(ctx, nil, nil, pods)
+		assert.Equal(t, map[types.Pod]float64{pausedPod: 1, slowPod: 1, fastPod: 1, newPod: 1}, got)
+	})
+}
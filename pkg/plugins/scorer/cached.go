@@ -0,0 +1,218 @@
+package scorer
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// CachedType is the type of the Cached scorer.
+	CachedType = "cached-scorer"
+
+	defaultCachedScoresTTL  = time.Second
+	defaultMaxCachedResults = 1000
+)
+
+// CachedParameters defines the parameters for the Cached scorer.
+type CachedParameters struct {
+	// Scorer is the name of the scorer plugin whose results are cached.
+	Scorer string `json:"scorer"`
+	// TTL defines how long a cached result is reused.
+	// This field accepts duration strings like "500ms", "2s".
+	TTL string `json:"ttl"`
+	// IgnorePrompt excludes the prompt from the cache key, for scorers not depending on it.
+	IgnorePrompt bool `json:"ignorePrompt"`
+	// Headers are the names of the request headers the cached scorer depends on, included in the cache key.
+	Headers []string `json:"headers"`
+	// MaxEntries is the maximal number of cached results; the least recently used are dropped first.
+	MaxEntries int `json:"maxEntries"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &Cached{}
+
+// CachedFactory defines the factory function for the Cached scorer.
+func CachedFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := CachedParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", CachedType, err)
+		}
+	}
+
+	scorer, err := plugins.PluginByType[framework.Scorer](handle, parameters.Scorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the cached scorer of the '%s' scorer - %w", CachedType, err)
+	}
+	// a cached result skips the Score call of the cached scorer, losing the state it writes there
+	if scorer.TypedName().Type == prefix.PrefixCachePluginType {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - the '%s' scorer writes the scheduling cycle state and cannot be cached",
+			CachedType, prefix.PrefixCachePluginType)
+	}
+
+	return NewCached(handle.Context(), scorer, &parameters).WithName(name), nil
+}
+
+// NewCached creates a new Cached scorer caching the results of the given scorer.
+// The Scorer field of the parameters is ignored.
+func NewCached(ctx context.Context, scorer framework.Scorer, params *CachedParameters) *Cached {
+	ttl := defaultCachedScoresTTL
+	maxEntries := defaultMaxCachedResults
+	ignorePrompt := false
+	var headers []string
+
+	if params != nil {
+		ignorePrompt = params.IgnorePrompt
+		headers = slices.Clone(params.Headers)
+		if params.MaxEntries > 0 {
+			maxEntries = params.MaxEntries
+		}
+		if params.TTL != "" {
+			paramsTTL, err := time.ParseDuration(params.TTL)
+			if err != nil || paramsTTL <= 0 {
+				log.FromContext(ctx).Error(err, "Invalid cached scores ttl duration, using default ttl")
+			} else {
+				ttl = paramsTTL
+			}
+		}
+	}
+
+	return &Cached{
+		typedName:    plugins.TypedName{Type: CachedType},
+		scorer:       scorer,
+		ignorePrompt: ignorePrompt,
+		headers:      headers,
+		results: ttlcache.New[uint64, map[string]float64](
+			ttlcache.WithTTL[uint64, map[string]float64](ttl),
+			ttlcache.WithCapacity[uint64, map[string]float64](uint64(maxEntries)),
+			ttlcache.WithDisableTouchOnHit[uint64, map[string]float64](),
+		),
+	}
+}
+
+// Cached is a scorer that caches the results of another scorer, to avoid recomputing identical
+// scores for similar requests while the pods are stable. A result is reused for requests with the
+// same target model, prompt (unless ignored) and configured headers, as long as the candidate pods
+// and their metrics are unchanged and the result did not expire.
+//
+// Only scorers whose scores are determined by these inputs should be cached; the results of
+// scorers keeping their own state, e.g. in-flight requests, may be stale for up to the ttl.
+// A cached result is returned without calling the cached scorer, so scorers writing the cycle
+// state or the plugin state in Score, such as the prefix cache scorer, must not be cached.
+type Cached struct {
+	typedName    plugins.TypedName
+	scorer       framework.Scorer
+	ignorePrompt bool
+	headers      []string
+
+	// results holds the scores of the cached scorer by pod name, keyed by the request features
+	// and the version of the candidate pods
+	results *ttlcache.Cache[uint64, map[string]float64]
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Cached) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *Cached) WithName(name string) *Cached {
+	s.typedName.Name = name
+	return s
+}
+
+// Score returns the cached scores of the given pods if available, otherwise it scores them with
+// the cached scorer and caches the result.
+func (s *Cached) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	logger := log.FromContext(ctx).V(logutil.TRACE)
+	key := s.cacheKey(request, pods)
+
+	if item := s.results.Get(key); item != nil {
+		logger.Info("Using cached scores", "scorer", s.scorer.TypedName())
+		cachedScores := item.Value()
+		scoredPods := make(map[types.Pod]float64, len(pods))
+		for _, pod := range pods {
+			scoredPods[pod] = cachedScores[pod.GetPod().NamespacedName.String()]
+		}
+		return scoredPods
+	}
+
+	scoredPods := s.scorer.Score(ctx, cycleState, request, pods)
+	cachedScores := make(map[string]float64, len(scoredPods))
+	for pod, score := range scoredPods {
+		cachedScores[pod.GetPod().NamespacedName.String()] = score
+	}
+	s.results.Set(key, cachedScores, ttlcache.DefaultTTL)
+	logger.Info("Cached scores", "scorer", s.scorer.TypedName())
+
+	return scoredPods
+}
+
+// cacheKey returns the key of the scores of the given request and pods, combining the relevant
+// request features with the version of the pods.
+func (s *Cached) cacheKey(request *types.LLMRequest, pods []types.Pod) uint64 {
+	h := fnv.New64a()
+	if request != nil {
+		_, _ = h.Write([]byte(request.TargetModel))
+		_, _ = h.Write([]byte{0})
+		if !s.ignorePrompt {
+			_, _ = h.Write([]byte(request.Prompt))
+		}
+		_, _ = h.Write([]byte{0})
+		for _, header := range s.headers {
+			_, _ = h.Write([]byte(request.Headers[header]))
+			_, _ = h.Write([]byte{0})
+		}
+	}
+
+	writePodSetVersion(h, pods)
+	return h.Sum64()
+}
+
+// writePodSetVersion writes a version of the given pods to the given hash, which changes whenever a pod
+// is added or removed, or the address or the metrics of a pod change. The pods are written in the order
+// of their names, so the version does not depend on the order of the pods.
+func writePodSetVersion(h hash.Hash64, pods []types.Pod) {
+	sorted := slices.Clone(pods)
+	slices.SortFunc(sorted, func(a, b types.Pod) int {
+		return strings.Compare(a.GetPod().NamespacedName.String(), b.GetPod().NamespacedName.String())
+	})
+
+	var buf [8]byte
+	for _, pod := range sorted {
+		_, _ = h.Write([]byte(pod.GetPod().NamespacedName.String()))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(pod.GetPod().Address))
+		_, _ = h.Write([]byte{0})
+		if metrics := pod.GetMetrics(); metrics != nil {
+			for _, value := range []uint64{
+				uint64(metrics.WaitingQueueSize),
+				uint64(metrics.RunningQueueSize),
+				math.Float64bits(metrics.KVCacheUsagePercent),
+				uint64(metrics.KvCacheMaxTokenCapacity),
+			} {
+				binary.LittleEndian.PutUint64(buf[:], value)
+				_, _ = h.Write(buf[:])
+			}
+		}
+		_, _ = h.Write([]byte{0})
+	}
+}
@@ -0,0 +1,171 @@
+package scorer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+// countingScorer is a test scorer counting its invocations, scoring pods by their waiting queue size.
+type countingScorer struct {
+	calls int
+}
+
+func (s *countingScorer) TypedName() plugins.TypedName {
+	return plugins.TypedName{Type: "counting", Name: "counting"}
+}
+
+func (s *countingScorer) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	s.calls++
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 1.0 / float64(1+pod.GetMetrics().WaitingQueueSize)
+	}
+	return scoredPods
+}
+
+func TestCached_Score(t *testing.T) {
+	ctx := context.Background()
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	podB := createPod("pod-b", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 1})
+	request := &types.LLMRequest{TargetModel: "model", Prompt: "hello", Headers: map[string]string{"x-tenant": "a"}}
+
+	inner := &countingScorer{}
+	s := scorer.NewCached(ctx, inner, &scorer.CachedParameters{TTL: "1m", Headers: []string{"x-tenant"}})
+
+	got := s.Score(ctx, nil, request, []types.Pod{podA, podB})
+	assert.Equal(t, map[types.Pod]float64{podA: 1, podB: 0.5}, got)
+	assert.Equal(t, 1, inner.calls)
+
+	t.Run("identical request and pods are served from the cache", func(t *testing.T) {
+		// refreshed pod objects with the same metrics, in a different order
+		refreshedA, refreshedB := createPod("pod-a", "", nil, backendmetrics.MetricsState{}), createPod("pod-b", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 1})
+		got := s.Score(ctx, nil, request, []types.Pod{refreshedB, refreshedA})
+		assert.Equal(t, map[types.Pod]float64{refreshedA: 1, refreshedB: 0.5}, got)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("pod set change invalidates the cache", func(t *testing.T) {
+		podC := createPod("pod-c", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 3})
+		got := s.Score(ctx, nil, request, []types.Pod{podA, podB, podC})
+		assert.Equal(t, map[types.Pod]float64{podA: 1, podB: 0.5, podC: 0.25}, got)
+		assert.Equal(t, 2, inner.calls)
+
+		got = s.Score(ctx, nil, request, []types.Pod{podA})
+		assert.Equal(t, map[types.Pod]float64{podA: 1}, got)
+		assert.Equal(t, 3, inner.calls)
+	})
+
+	t.Run("metrics change invalidates the cache", func(t *testing.T) {
+		loadedB := createPod("pod-b", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 3})
+		got := s.Score(ctx, nil, request, []types.Pod{podA, loadedB})
+		assert.Equal(t, map[types.Pod]float64{podA: 1, loadedB: 0.25}, got)
+		assert.Equal(t, 4, inner.calls)
+	})
+
+	t.Run("different request features are not served from the cache", func(t *testing.T) {
+		for i, other := range []*types.LLMRequest{
+			{TargetModel: "other", Prompt: "hello", Headers: map[string]string{"x-tenant": "a"}},
+			{TargetModel: "model", Prompt: "bye", Headers: map[string]string{"x-tenant": "a"}},
+			{TargetModel: "model", Prompt: "hello", Headers: map[string]string{"x-tenant": "b"}},
+		} {
+			s.Score(ctx, nil, other, []types.Pod{podA, podB})
+			assert.Equal(t, 5+i, inner.calls)
+		}
+
+		// headers not included in the key do not matter
+		s.Score(ctx, nil, &types.LLMRequest{TargetModel: "model", Prompt: "hello",
+			Headers: map[string]string{"x-tenant": "a", "x-other": "value"}}, []types.Pod{podA, podB})
+		assert.Equal(t, 7, inner.calls)
+	})
+}
+
+func TestCached_IgnorePrompt(t *testing.T) {
+	ctx := context.Background()
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	inner := &countingScorer{}
+	s := scorer.NewCached(ctx, inner, &scorer.CachedParameters{IgnorePrompt: true})
+
+	s.Score(ctx, nil, &types.LLMRequest{Prompt: "hello"}, []types.Pod{pod})
+	s.Score(ctx, nil, &types.LLMRequest{Prompt: "bye"}, []types.Pod{pod})
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestCachedFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+	handle.AddPlugin("load", scorer.NewLoadAware(context.Background(), 128))
+
+	plugin, err := scorer.CachedFactory("cached-load", json.RawMessage(`{"scorer": "load", "ignorePrompt": true}`), handle)
+	assert.NoError(t, err)
+	assert.Equal(t, "cached-load", plugin.TypedName().Name)
+
+	_, err = scorer.CachedFactory("cached-load", json.RawMessage(`{"scorer": "missing"}`), handle)
+	assert.Error(t, err)
+
+	// the prefix cache scorer writes the cycle state, which a cached result would skip
+	handle.AddPlugin("prefix", prefix.New(context.Background(), prefix.DefaultConfig))
+	_, err = scorer.CachedFactory("cached-prefix", json.RawMessage(`{"scorer": "prefix"}`), handle)
+	assert.Error(t, err)
+}
+
+func BenchmarkCached_Score(b *testing.B) {
+	ctx := context.Background()
+	const dimensions = 256
+
+	embedding := func(seed int) string {
+		values := make([]string, dimensions)
+		for i := range values {
+			values[i] = strconv.Itoa((seed*31 + i*17) % 97)
+		}
+		return strings.Join(values, ",")
+	}
+
+	// an expensive scorer comparing the request embedding with 64 embeddings on each of 100 pods
+	embeddingSimilarity := scorer.NewEmbeddingSimilarity(&scorer.EmbeddingSimilarityParameters{EmbeddingsPerPod: 64})
+	pods := make([]types.Pod, 100)
+	for i := range pods {
+		pods[i] = &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"}},
+			MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: i % 10},
+		}
+		for j := range 64 {
+			request := &types.LLMRequest{Headers: map[string]string{"x-prompt-embedding": embedding(i*64 + j)}}
+			embeddingSimilarity.PreRequest(ctx, request, primaryResult(pods[i]), 0)
+		}
+	}
+	request := &types.LLMRequest{TargetModel: "model", Prompt: "hello", Headers: map[string]string{"x-prompt-embedding": embedding(0)}}
+
+	for _, bm := range []struct {
+		name   string
+		scorer framework.Scorer
+	}{
+		{name: "uncached", scorer: embeddingSimilarity},
+		{name: "cached", scorer: scorer.NewCached(ctx, embeddingSimilarity,
+			&scorer.CachedParameters{TTL: "1m", Headers: []string{"x-prompt-embedding"}})},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				bm.scorer.Score(ctx, nil, request, pods)
+			}
+		})
+	}
+}
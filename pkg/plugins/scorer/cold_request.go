@@ -0,0 +1,269 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// ColdRequestType is the type of the ColdRequest scorer.
+	ColdRequestType = "cold-request-scorer"
+
+	// coldRequestTimeout defines how long a request scored as cold waits for its PreRequest call.
+	coldRequestTimeout = time.Minute
+
+	// maxPendingColdRequests bounds the number of cold requests waiting for their PreRequest call.
+	maxPendingColdRequests = 10000
+
+	// coldRequestHalfLife defines the time it takes the number of cold requests seeded to a pod to decay by half.
+	coldRequestHalfLife = 10 * time.Minute
+
+	// coldRequestPodTimeout defines how long a pod that is no longer a candidate is remembered.
+	coldRequestPodTimeout = time.Hour
+)
+
+type coldRequestParameters struct {
+	// PrefixPluginName is the name of the prefix cache plugin whose state is used to detect prefix matches.
+	PrefixPluginName string `json:"prefixPluginName"`
+	// SessionScorer is the name of the session affinity scorer whose session transport is used to detect sessions.
+	SessionScorer string `json:"sessionScorer"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer          = &ColdRequest{}
+	_ requestcontrol.PreRequest = &ColdRequest{}
+)
+
+// ColdRequestFactory defines the factory function for the ColdRequest scorer.
+func ColdRequestFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := coldRequestParameters{PrefixPluginName: prefix.PrefixCachePluginType}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", ColdRequestType, err)
+		}
+	}
+
+	scorer := NewColdRequest(parameters.PrefixPluginName).WithName(name)
+	if parameters.SessionScorer != "" {
+		sessions, err := plugins.PluginByType[*SessionAffinity](handle, parameters.SessionScorer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the session scorer of the '%s' scorer - %w", ColdRequestType, err)
+		}
+		scorer = scorer.WithSessionAffinity(sessions)
+	}
+	return scorer, nil
+}
+
+// NewColdRequest creates a new ColdRequest scorer.
+// prefixPluginName - the name of the prefix cache plugin whose state is used to detect prefix matches
+func NewColdRequest(prefixPluginName string) *ColdRequest {
+	return &ColdRequest{
+		typedName:             plugins.TypedName{Type: ColdRequestType},
+		prefixPluginTypedName: plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefixPluginName},
+		coldRequests: ttlcache.New[string, struct{}](
+			ttlcache.WithTTL[string, struct{}](coldRequestTimeout),
+			ttlcache.WithCapacity[string, struct{}](maxPendingColdRequests),
+		),
+		sessions:   NewSessionAffinity(),
+		seededPods: make(map[string]*seededPod),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// seededPod holds the decaying number of cold requests sent to a pod.
+type seededPod struct {
+	// count is the number of cold requests sent to the pod as of updated
+	count   float64
+	updated time.Time
+	// lastSeen is the last time the pod was a candidate of a cold request
+	lastSeen time.Time
+}
+
+// countAt returns the number of cold requests sent to the pod, decayed to the given time.
+func (p *seededPod) countAt(now time.Time) float64 {
+	return p.count * math.Exp2(-now.Sub(p.updated).Seconds()/coldRequestHalfLife.Seconds())
+}
+
+// ColdRequest is a scorer that spreads cold requests, i.e., requests without a session and
+// without any prefix match, across pods in order to warm up the prefix cache evenly. Cold
+// requests prefer the pods that were recently seeded with the fewest cold requests, where the
+// number of cold requests sent to each pod decays over time. New pods start at the lowest count
+// of the other candidates, and pods that are no longer candidates are eventually forgotten.
+// All other requests score all pods equally, leaving the decision to the other scorers.
+// The prefix cache plugin must run before this scorer in the scheduling profile.
+type ColdRequest struct {
+	typedName             plugins.TypedName
+	prefixPluginTypedName plugins.TypedName
+
+	// coldRequests holds the IDs of the requests scored as cold, until their PreRequest call
+	coldRequests *ttlcache.Cache[string, struct{}]
+
+	// sessions detects the session of a request using its session transport
+	sessions *SessionAffinity
+
+	// seededPods maps each pod to the decaying number of cold requests sent to it
+	seededPods map[string]*seededPod
+	mutex      *sync.Mutex
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *ColdRequest) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *ColdRequest) WithName(name string) *ColdRequest {
+	s.typedName.Name = name
+	return s
+}
+
+// WithSessionAffinity sets the session affinity scorer whose session transport is used to detect
+// sessions. Defaults to the session token header.
+func (s *ColdRequest) WithSessionAffinity(sessions *SessionAffinity) *ColdRequest {
+	s.sessions = sessions
+	return s
+}
+
+// Score scores the given pods of a cold request by the number of cold requests that were recently
+// sent to them, normalized to a range of 0-1, where less warmed pods get higher scores.
+// For all other requests all pods are scored with 0.
+func (s *ColdRequest) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	if !s.isCold(ctx, cycleState, request) {
+		scoredPods := make(map[types.Pod]float64, len(pods))
+		for _, pod := range pods {
+			scoredPods[pod] = 0.0
+		}
+		return scoredPods
+	}
+	s.coldRequests.Set(request.RequestId, struct{}{}, ttlcache.DefaultTTL)
+
+	scoredPods := s.scoreAt(pods, time.Now())
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods of a cold request", "scores", scoredPods)
+	return scoredPods
+}
+
+// scoreAt scores the given pods of a cold request by their decayed seeded counts at the given time.
+func (s *ColdRequest) scoreAt(pods []types.Pod, now time.Time) map[types.Pod]float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.pruneLocked(now)
+
+	// pods seen for the first time start at the lowest count of the known candidates, so they
+	// do not take all cold requests until they catch up with the long running pods
+	counts := make(map[types.Pod]float64, len(pods))
+	minCount := math.Inf(1)
+	for _, pod := range pods {
+		if seeded, found := s.seededPods[pod.GetPod().NamespacedName.String()]; found {
+			seeded.lastSeen = now
+			counts[pod] = seeded.countAt(now)
+			minCount = min(minCount, counts[pod])
+		}
+	}
+	if math.IsInf(minCount, 1) {
+		minCount = 0
+	}
+
+	maxCount := minCount
+	for _, pod := range pods {
+		podName := pod.GetPod().NamespacedName.String()
+		if _, found := s.seededPods[podName]; !found {
+			s.seededPods[podName] = &seededPod{count: minCount, updated: now, lastSeen: now}
+			counts[pod] = minCount
+		}
+		maxCount = max(maxCount, counts[pod])
+	}
+
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		if maxCount == minCount {
+			scoredPods[pod] = 1.0
+		} else {
+			scoredPods[pod] = (maxCount - counts[pod]) / (maxCount - minCount)
+		}
+	}
+	return scoredPods
+}
+
+// PreRequest records the pods a cold request is sent to as seeded.
+func (s *ColdRequest) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	if _, found := s.coldRequests.GetAndDelete(request.RequestId); !found {
+		return
+	}
+
+	now := time.Now()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, profileResult := range schedulingResult.ProfileResults {
+		if profileResult == nil || len(profileResult.TargetPods) == 0 {
+			continue
+		}
+		podName := profileResult.TargetPods[0].GetPod().NamespacedName.String()
+		s.seedLocked(podName, now)
+		log.FromContext(ctx).V(logutil.TRACE).Info("Seeded pod with a cold request", "pod", podName)
+	}
+}
+
+// seedLocked records a cold request sent to the given pod at the given time. Must be called with the mutex held.
+func (s *ColdRequest) seedLocked(podName string, now time.Time) {
+	seeded, found := s.seededPods[podName]
+	if !found {
+		seeded = &seededPod{}
+		s.seededPods[podName] = seeded
+	}
+	seeded.count = seeded.countAt(now) + 1
+	seeded.updated = now
+	seeded.lastSeen = now
+}
+
+// pruneLocked forgets the pods that were not candidates of a cold request for a while, e.g., pods
+// that left the pool. Must be called with the mutex held.
+func (s *ColdRequest) pruneLocked(now time.Time) {
+	for podName, seeded := range s.seededPods {
+		if now.Sub(seeded.lastSeen) > coldRequestPodTimeout {
+			delete(s.seededPods, podName)
+		}
+	}
+}
+
+// isCold returns true if the given request has no session and no prefix match on any pod.
+func (s *ColdRequest) isCold(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest) bool {
+	if request == nil {
+		return false
+	}
+	if s.sessions.requestToken(request) != "" {
+		return false
+	}
+	if cycleState == nil {
+		return true
+	}
+
+	prefixState, err := types.ReadCycleStateKey[*prefix.SchedulingContextState](cycleState, plugins.StateKey(s.prefixPluginTypedName.String()))
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Unable to read prefix state, assuming no prefix match", "error", err)
+		return true
+	}
+	for _, hits := range prefixState.PrefixCacheServers {
+		if hits > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package scorer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestColdRequest_SpreadsColdRequests(t *testing.T) {
+	ctx := context.Background()
+	pods := []types.Pod{
+		createPod("pod-a", "", nil, backendmetrics.MetricsState{}),
+		createPod("pod-b", "", nil, backendmetrics.MetricsState{}),
+		createPod("pod-c", "", nil, backendmetrics.MetricsState{}),
+	}
+
+	s := NewColdRequest(prefix.PrefixCachePluginType)
+	prefixStateKey := plugins.StateKey(plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefix.PrefixCachePluginType}.String())
+
+	picked := map[string]int{}
+	for i := range 6 {
+		request := &types.LLMRequest{RequestId: fmt.Sprintf("request-%d", i), Prompt: fmt.Sprintf("new prompt %d", i)}
+		cycleState := types.NewCycleState()
+		cycleState.Write(prefixStateKey, &prefix.SchedulingContextState{PrefixCacheServers: map[prefix.ServerID]int{}})
+
+		scores := s.Score(ctx, cycleState, request, pods)
+
+		// pick the first pod with the highest score
+		var best types.Pod
+		for _, pod := range pods {
+			if best == nil || scores[pod] > scores[best] {
+				best = pod
+			}
+		}
+		picked[best.GetPod().NamespacedName.Name]++
+
+		s.PreRequest(ctx, request, &types.SchedulingResult{
+			ProfileResults: map[string]*types.ProfileRunResult{"default": {TargetPods: []types.Pod{best}}},
+		}, 0)
+	}
+
+	if diff := cmp.Diff(map[string]int{"pod-a": 2, "pod-b": 2, "pod-c": 2}, picked); diff != "" {
+		t.Errorf("Expected cold requests to spread evenly (-want +got): %v", diff)
+	}
+}
+
+func TestColdRequest_WarmRequests(t *testing.T) {
+	ctx := context.Background()
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	podB := createPod("pod-b", "", nil, backendmetrics.MetricsState{})
+	pods := []types.Pod{podA, podB}
+
+	s := NewColdRequest(prefix.PrefixCachePluginType)
+	prefixStateKey := plugins.StateKey(plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefix.PrefixCachePluginType}.String())
+
+	// seed pod-a with a cold request
+	coldState := types.NewCycleState()
+	coldState.Write(prefixStateKey, &prefix.SchedulingContextState{PrefixCacheServers: map[prefix.ServerID]int{}})
+	coldRequest := &types.LLMRequest{RequestId: "cold"}
+	s.Score(ctx, coldState, coldRequest, pods)
+	s.PreRequest(ctx, coldRequest, &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{"default": {TargetPods: []types.Pod{podA}}},
+	}, 0)
+
+	tests := []struct {
+		name    string
+		request *types.LLMRequest
+		hits    map[prefix.ServerID]int
+	}{
+		{
+			name:    "request with prefix match",
+			request: &types.LLMRequest{RequestId: "prefix"},
+			hits:    map[prefix.ServerID]int{prefix.ServerID(podA.GetPod().NamespacedName): 2},
+		},
+		{
+			name:    "request with session",
+			request: &types.LLMRequest{RequestId: "session", Headers: map[string]string{"x-session-token": "token"}},
+			hits:    map[prefix.ServerID]int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cycleState := types.NewCycleState()
+			cycleState.Write(prefixStateKey, &prefix.SchedulingContextState{PrefixCacheServers: test.hits})
+
+			got := s.Score(ctx, cycleState, test.request, pods)
+			if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0}, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestColdRequest_CookieSession(t *testing.T) {
+	ctx := context.Background()
+	pod := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	sessions, err := NewSessionAffinity().WithTransport(SessionTransportCookie, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s := NewColdRequest(prefix.PrefixCachePluginType).WithSessionAffinity(sessions)
+
+	tests := []struct {
+		name    string
+		request *types.LLMRequest
+		want    float64
+	}{
+		{
+			name:    "request with session cookie",
+			request: &types.LLMRequest{RequestId: "cookie", Headers: map[string]string{"cookie": "other=1; llm-d-session=token"}},
+			want:    0,
+		},
+		{
+			name:    "request with session header only",
+			request: &types.LLMRequest{RequestId: "header", Headers: map[string]string{"x-session-token": "token"}},
+			want:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.Score(ctx, nil, test.request, []types.Pod{pod})
+			if diff := cmp.Diff(map[types.Pod]float64{pod: test.want}, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestColdRequest_SeededCounts(t *testing.T) {
+	podA, podB, podC := createPod("pod-a", "", nil, backendmetrics.MetricsState{}), createPod("pod-b", "", nil, backendmetrics.MetricsState{}), createPod("pod-c", "", nil, backendmetrics.MetricsState{})
+	start := time.Now()
+
+	s := NewColdRequest(prefix.PrefixCachePluginType)
+	s.scoreAt([]types.Pod{podA, podB}, start)
+	for range 4 {
+		s.seedLocked("default/pod-a", start)
+	}
+	for range 2 {
+		s.seedLocked("default/pod-b", start)
+	}
+
+	// a new pod starts at the lowest count of the known candidates instead of 0
+	got := s.scoreAt([]types.Pod{podA, podB, podC}, start)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 1, podC: 1}, got); diff != "" {
+		t.Errorf("Unexpected output for a new pod (-want +got): %v", diff)
+	}
+
+	// counts decay by half every half-life, so a recently seeded pod ranks below a long seeded one
+	for range 2 {
+		s.seedLocked("default/pod-c", start.Add(coldRequestHalfLife))
+	}
+	got = s.scoreAt([]types.Pod{podA, podB, podC}, start.Add(coldRequestHalfLife))
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0.5, podB: 1, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output after decay (-want +got): %v", diff)
+	}
+
+	// pods that are no longer candidates are forgotten
+	s.scoreAt([]types.Pod{podC}, start.Add(coldRequestHalfLife+coldRequestPodTimeout+time.Second))
+	if _, found := s.seededPods["default/pod-a"]; found {
+		t.Errorf("Expected pod-a to be forgotten")
+	}
+	if _, found := s.seededPods["default/pod-c"]; !found {
+		t.Errorf("Expected pod-c to be remembered")
+	}
+}
@@ -0,0 +1,227 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// EmbeddingSimilarityType is the type of the EmbeddingSimilarity scorer.
+	EmbeddingSimilarityType = "embedding-similarity-scorer"
+
+	defaultEmbeddingHeader     = "x-prompt-embedding"
+	defaultEmbeddingsPerPod    = 64
+	defaultMinSimilarity       = 0.8
+	defaultEmbeddingPodTimeout = 10 * time.Minute
+)
+
+// EmbeddingSimilarityParameters defines the parameters for the EmbeddingSimilarity scorer.
+type EmbeddingSimilarityParameters struct {
+	// EmbeddingHeader is the name of the request header holding the prompt embedding, as comma
+	// separated numbers.
+	EmbeddingHeader string `json:"embeddingHeader"`
+	// EmbeddingsPerPod is the number of recent prompt embeddings kept per pod.
+	EmbeddingsPerPod int `json:"embeddingsPerPod"`
+	// MinSimilarity is the cosine similarity, in range of 0-1, below which prompts are not
+	// considered similar.
+	MinSimilarity float64 `json:"minSimilarity"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer          = &EmbeddingSimilarity{}
+	_ requestcontrol.PreRequest = &EmbeddingSimilarity{}
+)
+
+// EmbeddingSimilarityFactory defines the factory function for the EmbeddingSimilarity scorer.
+func EmbeddingSimilarityFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := EmbeddingSimilarityParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", EmbeddingSimilarityType, err)
+		}
+	}
+
+	return NewEmbeddingSimilarity(&parameters).WithName(name), nil
+}
+
+// NewEmbeddingSimilarity creates a new EmbeddingSimilarity scorer.
+func NewEmbeddingSimilarity(params *EmbeddingSimilarityParameters) *EmbeddingSimilarity {
+	embeddingHeader := defaultEmbeddingHeader
+	embeddingsPerPod := defaultEmbeddingsPerPod
+	minSimilarity := defaultMinSimilarity
+
+	if params != nil {
+		if params.EmbeddingHeader != "" {
+			embeddingHeader = params.EmbeddingHeader
+		}
+		if params.EmbeddingsPerPod > 0 {
+			embeddingsPerPod = params.EmbeddingsPerPod
+		}
+		if params.MinSimilarity > 0 && params.MinSimilarity <= 1 {
+			minSimilarity = params.MinSimilarity
+		}
+	}
+
+	return &EmbeddingSimilarity{
+		typedName:        plugins.TypedName{Type: EmbeddingSimilarityType},
+		embeddingHeader:  embeddingHeader,
+		embeddingsPerPod: embeddingsPerPod,
+		minSimilarity:    minSimilarity,
+		podEmbeddings: ttlcache.New[string, [][]float64](
+			ttlcache.WithTTL[string, [][]float64](defaultEmbeddingPodTimeout),
+			ttlcache.WithDisableTouchOnHit[string, [][]float64](),
+		),
+		mutex: &sync.Mutex{},
+	}
+}
+
+// EmbeddingSimilarity is a scorer that prefers the pods which recently served prompts semantically
+// similar to the request's prompt, allowing reuse beyond exact prefix matches. The prompt embedding
+// is provided in a request header, and is compared by cosine similarity against the embeddings of
+// the recent prompts of each pod.
+type EmbeddingSimilarity struct {
+	typedName        plugins.TypedName
+	embeddingHeader  string
+	embeddingsPerPod int
+	minSimilarity    float64
+
+	// podEmbeddings holds the embeddings of the recent prompts of each pod, oldest first.
+	// Stored slices are never modified, updates replace them under the mutex.
+	podEmbeddings *ttlcache.Cache[string, [][]float64]
+	mutex         *sync.Mutex
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *EmbeddingSimilarity) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *EmbeddingSimilarity) WithName(name string) *EmbeddingSimilarity {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores each pod by the highest cosine similarity between the request's prompt embedding and
+// the embeddings of the pod's recent prompts. Similarities below the minimal similarity are scored with 0,
+// as are all pods when the request has no valid embedding.
+func (s *EmbeddingSimilarity) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 0.0
+	}
+
+	embedding := s.requestEmbedding(ctx, request)
+	if embedding == nil {
+		return scoredPods
+	}
+
+	for _, pod := range pods {
+		item := s.podEmbeddings.Get(pod.GetPod().NamespacedName.String())
+		if item == nil {
+			continue
+		}
+		best := 0.0
+		for _, podEmbedding := range item.Value() {
+			best = max(best, cosineSimilarity(embedding, podEmbedding))
+		}
+		if best >= s.minSimilarity {
+			scoredPods[pod] = best
+		}
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods", "scores", scoredPods)
+	return scoredPods
+}
+
+// PreRequest records the request's prompt embedding on the pod chosen by the primary profile.
+func (s *EmbeddingSimilarity) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	embedding := s.requestEmbedding(ctx, request)
+	if embedding == nil {
+		return
+	}
+
+	profileResult := schedulingResult.ProfileResults[schedulingResult.PrimaryProfileName]
+	if profileResult == nil || len(profileResult.TargetPods) == 0 {
+		return
+	}
+	podName := profileResult.TargetPods[0].GetPod().NamespacedName.String()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var embeddings [][]float64
+	if item := s.podEmbeddings.Get(podName); item != nil {
+		embeddings = item.Value()
+	}
+	if len(embeddings) >= s.embeddingsPerPod {
+		embeddings = embeddings[len(embeddings)-s.embeddingsPerPod+1:]
+	}
+	updated := make([][]float64, 0, len(embeddings)+1)
+	updated = append(append(updated, embeddings...), embedding)
+	s.podEmbeddings.Set(podName, updated, ttlcache.DefaultTTL)
+}
+
+// requestEmbedding returns the normalized prompt embedding of the given request, or nil if it has
+// no valid embedding.
+func (s *EmbeddingSimilarity) requestEmbedding(ctx context.Context, request *types.LLMRequest) []float64 {
+	if request == nil {
+		return nil
+	}
+	header := request.Headers[s.embeddingHeader]
+	if header == "" {
+		return nil
+	}
+
+	fields := strings.Split(header, ",")
+	embedding := make([]float64, len(fields))
+	norm := 0.0
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Ignoring invalid prompt embedding", "header", s.embeddingHeader)
+			return nil
+		}
+		embedding[i] = value
+		norm += value * value
+	}
+	if norm == 0 {
+		return nil
+	}
+
+	norm = math.Sqrt(norm)
+	for i := range embedding {
+		embedding[i] /= norm
+	}
+	return embedding
+}
+
+// cosineSimilarity returns the cosine similarity of the given normalized embeddings,
+// or 0 if their dimensions differ.
+func cosineSimilarity(a []float64, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	similarity := 0.0
+	for i := range a {
+		similarity += a[i] * b[i]
+	}
+	return similarity
+}
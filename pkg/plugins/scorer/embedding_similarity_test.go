@@ -0,0 +1,78 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestEmbeddingSimilarity_Score(t *testing.T) {
+	ctx := context.Background()
+
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podC := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-c", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{podA, podB, podC}
+
+	request := func(embedding string) *types.LLMRequest {
+		return &types.LLMRequest{Headers: map[string]string{"x-prompt-embedding": embedding}}
+	}
+
+	s := scorer.NewEmbeddingSimilarity(&scorer.EmbeddingSimilarityParameters{EmbeddingsPerPod: 2, MinSimilarity: 0.5})
+
+	// podA served weather prompts, podB served cooking prompts
+	s.PreRequest(ctx, request("1, 0, 0"), primaryResult(podA), 0)
+	s.PreRequest(ctx, request("0, 1, 0"), primaryResult(podB), 0)
+	s.PreRequest(ctx, request("0.1, 1, 0.1"), primaryResult(podB), 0)
+
+	t.Run("semantically nearest pod is preferred", func(t *testing.T) {
+		got := s.Score(ctx, nil, request("0.9, 0.2, 0"), pods)
+		assert.Greater(t, got[podA], got[podB])
+		assert.Equal(t, 0.0, got[podC])
+
+		got = s.Score(ctx, nil, request("0.2, 0.9, 0"), pods)
+		assert.Greater(t, got[podB], got[podA])
+		assert.Equal(t, 0.0, got[podC])
+	})
+
+	t.Run("identical embedding scores 1", func(t *testing.T) {
+		got := s.Score(ctx, nil, request("2, 0, 0"), pods)
+		assert.InDelta(t, 1.0, got[podA], 1e-9)
+	})
+
+	t.Run("dissimilar prompts are not boosted", func(t *testing.T) {
+		got := s.Score(ctx, nil, request("0, 0, 1"), pods)
+		assert.Equal(t, map[types.Pod]float64{podA: 0, podB: 0, podC: 0}, got)
+	})
+
+	t.Run("requests without a valid embedding are not boosted", func(t *testing.T) {
+		for _, embedding := range []string{"", "1, x, 0", "0, 0, 0", "1, 0"} {
+			got := s.Score(ctx, nil, request(embedding), pods)
+			assert.Equal(t, map[types.Pod]float64{podA: 0, podB: 0, podC: 0}, got, "embedding %q", embedding)
+		}
+	})
+
+	t.Run("oldest embeddings are dropped", func(t *testing.T) {
+		s.PreRequest(ctx, request("0, 0, 1"), primaryResult(podA), 0)
+		s.PreRequest(ctx, request("0, 0.1, 1"), primaryResult(podA), 0)
+
+		got := s.Score(ctx, nil, request("1, 0, 0"), pods)
+		assert.Equal(t, 0.0, got[podA])
+	})
+}
@@ -0,0 +1,155 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// ExpectedTTFTType is the type of the ExpectedTTFT scorer.
+	ExpectedTTFTType = "expected-ttft-scorer"
+)
+
+// ExpectedTTFTParameters defines the parameters for the ExpectedTTFT scorer.
+type ExpectedTTFTParameters struct {
+	// Window defines the sliding window over which the base time to first token is measured.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	Window string `json:"window"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer            = &ExpectedTTFT{}
+	_ requestcontrol.PreRequest   = &ExpectedTTFT{}
+	_ requestcontrol.PostResponse = &ExpectedTTFT{}
+)
+
+// ExpectedTTFTFactory defines the factory function for the ExpectedTTFT scorer.
+func ExpectedTTFTFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := ExpectedTTFTParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", ExpectedTTFTType, err)
+		}
+	}
+
+	return NewExpectedTTFT(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewExpectedTTFT creates a new ExpectedTTFT scorer.
+func NewExpectedTTFT(ctx context.Context, params *ExpectedTTFTParameters) *ExpectedTTFT {
+	window := defaultLatencyWindow
+
+	if params != nil && params.Window != "" {
+		paramsWindow, err := time.ParseDuration(params.Window)
+		if err != nil || paramsWindow <= 0 {
+			log.FromContext(ctx).Error(err, "Invalid TTFT window duration, using default window")
+		} else {
+			window = paramsWindow
+		}
+	}
+
+	return &ExpectedTTFT{
+		typedName: plugins.TypedName{Type: ExpectedTTFTType},
+		tracker:   newLatencyTracker(window, defaultRequestTimeout),
+	}
+}
+
+// ExpectedTTFT is a scorer that prefers pods with the lowest expected time to first token under
+// their current load. The time to first token of each request is measured from sending it to the
+// pod until receiving its response headers. A pod's base time to first token is the median of its
+// recent measurements, and its expected time to first token is the base multiplied by the number
+// of requests it has to process first, i.e. its waiting queue size plus the request itself.
+type ExpectedTTFT struct {
+	typedName plugins.TypedName
+	tracker   *latencyTracker
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *ExpectedTTFT) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *ExpectedTTFT) WithName(name string) *ExpectedTTFT {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods in range of 0-1, the pod with the lowest expected time to first
+// token is scored with 1 and the others by the ratio of the lowest expected time to theirs.
+// Pods without recent measurements are assumed to have the average base time to first token of
+// the measured pods. If no pod was measured, all pods are scored with 1.
+func (s *ExpectedTTFT) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+
+	baseTTFTs := make(map[types.Pod]time.Duration, len(pods))
+	var totalBaseTTFT time.Duration
+	for _, pod := range pods {
+		latencies := s.tracker.latencies(pod.GetPod().NamespacedName.String())
+		if len(latencies) == 0 {
+			continue
+		}
+		slices.Sort(latencies)
+		baseTTFTs[pod] = latencies[len(latencies)/2]
+		totalBaseTTFT += baseTTFTs[pod]
+	}
+
+	if len(baseTTFTs) == 0 {
+		for _, pod := range pods {
+			scoredPods[pod] = 1.0
+		}
+		return scoredPods
+	}
+	averageBaseTTFT := totalBaseTTFT / time.Duration(len(baseTTFTs))
+
+	expectedTTFTs := make(map[types.Pod]float64, len(pods))
+	minExpectedTTFT := -1.0
+	for _, pod := range pods {
+		baseTTFT, found := baseTTFTs[pod]
+		if !found {
+			baseTTFT = averageBaseTTFT
+		}
+		expectedTTFT := float64(baseTTFT) * float64(pod.GetMetrics().WaitingQueueSize+1)
+		expectedTTFTs[pod] = expectedTTFT
+		if minExpectedTTFT < 0 || expectedTTFT < minExpectedTTFT {
+			minExpectedTTFT = expectedTTFT
+		}
+	}
+
+	for pod, expectedTTFT := range expectedTTFTs {
+		if expectedTTFT == 0 {
+			scoredPods[pod] = 1.0
+		} else {
+			scoredPods[pod] = minExpectedTTFT / expectedTTFT
+		}
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods", "scores", scoredPods)
+	return scoredPods
+}
+
+// PreRequest records the time the request is sent to its target pod.
+func (s *ExpectedTTFT) PreRequest(_ context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	s.tracker.requestSent(request, schedulingResult)
+}
+
+// PostResponse records the time to first token of the request on the pod that served it.
+func (s *ExpectedTTFT) PostResponse(ctx context.Context, request *types.LLMRequest, _ *requestcontrol.Response, targetPod *backend.Pod) {
+	if ttft, found := s.tracker.responseReceived(request, targetPod); found {
+		log.FromContext(ctx).V(logutil.TRACE).Info("Recorded time to first token", "pod", targetPod.NamespacedName, "ttft", ttft)
+	}
+}
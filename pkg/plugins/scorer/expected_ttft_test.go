@@ -0,0 +1,76 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestExpectedTTFT_Score(t *testing.T) {
+	ctx := context.Background()
+
+	fastPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "fast", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	slowPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "slow", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	newPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "new", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{fastPod, slowPod, newPod}
+
+	s := scorer.NewExpectedTTFT(ctx, nil)
+
+	// no measurements, all pods are scored equally
+	got := s.Score(ctx, nil, nil, pods)
+	assert.Equal(t, map[types.Pod]float64{fastPod: 1, slowPod: 1, newPod: 1}, got)
+
+	// base TTFT of ~20ms on the fast pod and ~80ms on the slow pod
+	fastRequest := &types.LLMRequest{RequestId: "fast"}
+	slowRequest := &types.LLMRequest{RequestId: "slow"}
+	s.PreRequest(ctx, fastRequest, primaryResult(fastPod), 0)
+	s.PreRequest(ctx, slowRequest, primaryResult(slowPod), 0)
+	time.Sleep(20 * time.Millisecond)
+	s.PostResponse(ctx, fastRequest, &requestcontrol.Response{}, fastPod.GetPod())
+	time.Sleep(60 * time.Millisecond)
+	s.PostResponse(ctx, slowRequest, &requestcontrol.Response{}, slowPod.GetPod())
+
+	t.Run("idle pods are ranked by base TTFT", func(t *testing.T) {
+		got := s.Score(ctx, nil, nil, pods)
+		assert.Equal(t, 1.0, got[fastPod])
+		assert.Greater(t, got[newPod], got[slowPod])
+		assert.Greater(t, got[fastPod], got[newPod])
+	})
+
+	t.Run("queue depth outweighs a lower base TTFT", func(t *testing.T) {
+		fastPod.WaitingQueueSize = 5
+		defer func() { fastPod.WaitingQueueSize = 0 }()
+
+		got := s.Score(ctx, nil, nil, pods)
+		assert.Equal(t, 1.0, got[newPod])
+		assert.Greater(t, got[slowPod], got[fastPod])
+	})
+
+	t.Run("short queue keeps the lower base TTFT preferred", func(t *testing.T) {
+		fastPod.WaitingQueueSize = 1
+		defer func() { fastPod.WaitingQueueSize = 0 }()
+
+		got := s.Score(ctx, nil, nil, []types.Pod{fastPod, slowPod})
+		// ~40ms expected on the fast pod against ~80ms on the slow pod
+		assert.Equal(t, 1.0, got[fastPod])
+		assert.Less(t, got[slowPod], 1.0)
+	})
+}
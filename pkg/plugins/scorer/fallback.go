@@ -0,0 +1,132 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// FallbackType is the type of the Fallback scorer.
+	FallbackType = "fallback-scorer"
+)
+
+// DataCoverageReporter is implemented by scorers whose scoring quality depends on
+// the amount of data they have accumulated (e.g., the number of indexed prefix blocks).
+type DataCoverageReporter interface {
+	// DataCoverage returns the amount of data currently held by the scorer.
+	DataCoverage() int
+}
+
+type fallbackParameters struct {
+	// PrimaryScorer is the name of the scorer plugin that is preferred once it has enough data.
+	PrimaryScorer string `json:"primaryScorer"`
+	// FallbackScorer is the name of the scorer plugin used while the primary scorer lacks data.
+	FallbackScorer string `json:"fallbackScorer"`
+	// CoverageThreshold is the data coverage at which the primary scorer gets its full weight.
+	CoverageThreshold int `json:"coverageThreshold"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &Fallback{}
+
+// FallbackFactory defines the factory function for the Fallback scorer.
+func FallbackFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := fallbackParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", FallbackType, err)
+		}
+	}
+
+	primary, err := plugins.PluginByType[framework.Scorer](handle, parameters.PrimaryScorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the primary scorer of the '%s' scorer - %w", FallbackType, err)
+	}
+	fallback, err := plugins.PluginByType[framework.Scorer](handle, parameters.FallbackScorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the fallback scorer of the '%s' scorer - %w", FallbackType, err)
+	}
+
+	scorer, err := NewFallback(primary, fallback, parameters.CoverageThreshold)
+	if err != nil {
+		return nil, err
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewFallback creates a new Fallback scorer.
+// primary - the scorer to prefer once it has enough data, considered to always have enough data
+// unless it implements DataCoverageReporter
+// fallback - the scorer to use while the primary scorer lacks data
+// coverageThreshold - the data coverage at which the primary scorer fully replaces the fallback scorer
+func NewFallback(primary framework.Scorer, fallback framework.Scorer, coverageThreshold int) (*Fallback, error) {
+	reporter, _ := primary.(DataCoverageReporter)
+	if coverageThreshold <= 0 {
+		return nil, errors.New("coverageThreshold must be positive")
+	}
+
+	return &Fallback{
+		typedName:         plugins.TypedName{Type: FallbackType},
+		primary:           primary,
+		fallback:          fallback,
+		reporter:          reporter,
+		coverageThreshold: coverageThreshold,
+	}, nil
+}
+
+// Fallback is a scorer that blends a primary scorer with a fallback scorer based on the
+// data coverage of the primary scorer. While the primary scorer has little data, the
+// fallback scorer dominates; as data accumulates, weight shifts linearly towards the
+// primary scorer until the coverage threshold is reached. A primary scorer not reporting its
+// data coverage is considered to have enough data.
+type Fallback struct {
+	typedName plugins.TypedName
+	primary   framework.Scorer
+	fallback  framework.Scorer
+	// reporter reports the data coverage of the primary scorer, nil if it does not report it
+	reporter          DataCoverageReporter
+	coverageThreshold int
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Fallback) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *Fallback) WithName(name string) *Fallback {
+	s.typedName.Name = name
+	return s
+}
+
+// PrimaryWeight returns the relative weight, in range of 0-1, currently given to the primary scorer.
+func (s *Fallback) PrimaryWeight() float64 {
+	if s.reporter == nil {
+		return 1.0
+	}
+	return min(float64(s.reporter.DataCoverage())/float64(s.coverageThreshold), 1.0)
+}
+
+// Score scores the given pods by blending the scores of the primary and fallback scorers
+// according to the primary scorer's data coverage. Both scorers are always invoked, even when
+// one of them is weighted out, since scorers such as the prefix cache plugin record the cycle
+// state read by other plugins when scoring.
+func (s *Fallback) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	primaryWeight := s.PrimaryWeight()
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Blending primary and fallback scores", "primaryWeight", primaryWeight)
+
+	primaryScores := s.primary.Score(ctx, cycleState, request, pods)
+	fallbackScores := s.fallback.Score(ctx, cycleState, request, pods)
+
+	return blendScores(pods, primaryScores, fallbackScores, primaryWeight)
+}
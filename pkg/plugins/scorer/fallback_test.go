@@ -0,0 +1,147 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+// fixedScorer is a test scorer returning pre-defined scores.
+type fixedScorer struct {
+	scores   map[string]float64
+	coverage int
+	// calls counts the invocations of Score
+	calls int
+}
+
+func (s *fixedScorer) TypedName() plugins.TypedName {
+	return plugins.TypedName{Type: "fixed", Name: "fixed"}
+}
+
+func (s *fixedScorer) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	s.calls++
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = s.scores[pod.GetPod().NamespacedName.Name]
+	}
+	return scoredPods
+}
+
+func (s *fixedScorer) DataCoverage() int {
+	return s.coverage
+}
+
+func TestFallback_Score(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	tests := []struct {
+		name              string
+		coverage          int
+		wantPrimaryWeight float64
+		wantScores        map[types.Pod]float64
+	}{
+		{
+			name:              "no data, fallback only",
+			coverage:          0,
+			wantPrimaryWeight: 0,
+			wantScores:        map[types.Pod]float64{podA: 0, podB: 1},
+		},
+		{
+			name:              "partial data, blended",
+			coverage:          25,
+			wantPrimaryWeight: 0.25,
+			wantScores:        map[types.Pod]float64{podA: 0.25, podB: 0.75},
+		},
+		{
+			name:              "threshold reached, primary only",
+			coverage:          100,
+			wantPrimaryWeight: 1,
+			wantScores:        map[types.Pod]float64{podA: 1, podB: 0},
+		},
+		{
+			name:              "above threshold, primary only",
+			coverage:          1000,
+			wantPrimaryWeight: 1,
+			wantScores:        map[types.Pod]float64{podA: 1, podB: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			primary := &fixedScorer{scores: map[string]float64{"pod-a": 1, "pod-b": 0}, coverage: test.coverage}
+			fallback := &fixedScorer{scores: map[string]float64{"pod-a": 0, "pod-b": 1}}
+
+			s, err := scorer.NewFallback(primary, fallback, 100)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := s.PrimaryWeight(); got != test.wantPrimaryWeight {
+				t.Errorf("Unexpected primary weight, want %v, got %v", test.wantPrimaryWeight, got)
+			}
+
+			got := s.Score(context.Background(), nil, &types.LLMRequest{}, []types.Pod{podA, podB})
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestFallback_InvalidConfiguration(t *testing.T) {
+	primary := &fixedScorer{}
+	fallback := &fixedScorer{}
+
+	if _, err := scorer.NewFallback(primary, fallback, 0); err == nil {
+		t.Error("Expected error for non-positive coverage threshold")
+	}
+}
+
+func TestFallback_PrimaryWithoutCoverage(t *testing.T) {
+	// a primary scorer not reporting its data coverage is considered to have enough data
+	s, err := scorer.NewFallback(scorer.NewSessionAffinity(), &fixedScorer{}, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.PrimaryWeight(); got != 1 {
+		t.Errorf("Unexpected primary weight, want 1, got %v", got)
+	}
+}
+
+func TestFallback_InvokesBothScorers(t *testing.T) {
+	pods := []types.Pod{&types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}}
+
+	// scorers weighted out are still invoked, as they may record the cycle state read by other plugins
+	for _, coverage := range []int{0, 100} {
+		primary := &fixedScorer{coverage: coverage}
+		fallback := &fixedScorer{}
+		s, err := scorer.NewFallback(primary, fallback, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.Score(context.Background(), nil, &types.LLMRequest{}, pods)
+		if primary.calls != 1 || fallback.calls != 1 {
+			t.Errorf("Expected both scorers to be invoked at coverage %d, got primary %d and fallback %d",
+				coverage, primary.calls, fallback.calls)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// GoodputType is the type of the Goodput scorer.
+	GoodputType = "goodput-scorer"
+
+	// defaultGoodputWindow defines the default sliding window over which goodput is measured.
+	defaultGoodputWindow = time.Minute
+
+	// statusHeader is the pseudo-header holding the HTTP status of the response
+	statusHeader = ":status"
+
+	// goodputPriorSuccesses defines the number of successful responses every pod starts with, so pods
+	// with few responses are not judged by a handful of outcomes
+	goodputPriorSuccesses = 1
+	// maxGoodputSamples bounds the number of response outcomes kept per pod.
+	maxGoodputSamples = 1000
+)
+
+// GoodputParameters defines the parameters for the Goodput scorer.
+type GoodputParameters struct {
+	// Window defines the sliding window over which goodput is measured.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	Window string `json:"window"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer            = &Goodput{}
+	_ requestcontrol.PostResponse = &Goodput{}
+)
+
+// GoodputFactory defines the factory function for the Goodput scorer.
+func GoodputFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := GoodputParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", GoodputType, err)
+		}
+	}
+
+	return NewGoodput(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewGoodput creates a new Goodput scorer.
+func NewGoodput(ctx context.Context, params *GoodputParameters) *Goodput {
+	window := defaultGoodputWindow
+
+	if params != nil && params.Window != "" {
+		paramsWindow, err := time.ParseDuration(params.Window)
+		if err != nil || paramsWindow <= 0 {
+			log.FromContext(ctx).Error(err, "Invalid goodput window duration, using default window")
+		} else {
+			window = paramsWindow
+		}
+	}
+
+	return &Goodput{
+		typedName: plugins.TypedName{Type: GoodputType},
+		window:    window,
+		responses: make(map[string][]responseOutcome),
+		mutex:     &sync.Mutex{},
+	}
+}
+
+// responseOutcome records the outcome of a single response
+type responseOutcome struct {
+	time    time.Time
+	success bool
+}
+
+// Goodput is a scorer that prefers pods with high goodput, i.e., the share of successfully
+// delivered responses over a sliding window. Unlike raw throughput, failed responses do not
+// count. The share rather than the number of successful responses is scored, so lightly used
+// pods are not penalized in favor of the busiest pod. Since token counts are not available when
+// the response headers are processed, goodput is measured in successful responses rather than
+// successful tokens.
+type Goodput struct {
+	typedName plugins.TypedName
+	window    time.Duration
+
+	// responses holds the outcomes of the responses within the window per pod
+	responses map[string][]responseOutcome
+	mutex     *sync.Mutex
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Goodput) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *Goodput) WithName(name string) *Goodput {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by their share of successful responses within the window, in range
+// of 0-1. Every pod starts with a prior successful response, so pods without responses in the window
+// are scored with 1.
+func (s *Goodput) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	now := time.Now()
+	scoredPods := make(map[types.Pod]float64, len(pods))
+
+	s.mutex.Lock()
+	for _, pod := range pods {
+		outcomes := s.pruneLocked(pod.GetPod().NamespacedName.String(), now)
+		successes := 0
+		for _, outcome := range outcomes {
+			if outcome.success {
+				successes++
+			}
+		}
+		scoredPods[pod] = float64(successes+goodputPriorSuccesses) / float64(len(outcomes)+goodputPriorSuccesses)
+	}
+	s.mutex.Unlock()
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods", "scores", scoredPods)
+	return scoredPods
+}
+
+// PostResponse records the outcome of the response, based on its HTTP status, for the target pod.
+func (s *Goodput) PostResponse(ctx context.Context, _ *types.LLMRequest, response *requestcontrol.Response, targetPod *backend.Pod) {
+	if targetPod == nil || response == nil {
+		return
+	}
+	status, found := response.Headers[statusHeader]
+	if !found {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Response has no status, ignoring it", "pod", targetPod.NamespacedName)
+		return
+	}
+
+	podName := targetPod.NamespacedName.String()
+	now := time.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	outcomes := append(s.pruneLocked(podName, now), responseOutcome{time: now, success: strings.HasPrefix(status, "2")})
+	if len(outcomes) > maxGoodputSamples {
+		outcomes = outcomes[len(outcomes)-maxGoodputSamples:]
+	}
+	s.responses[podName] = outcomes
+}
+
+// pruneLocked drops the outcomes of the given pod that are outside the window and returns
+// the remaining ones. The mutex must be held by the caller.
+func (s *Goodput) pruneLocked(podName string, now time.Time) []responseOutcome {
+	outcomes := s.responses[podName]
+	start := 0
+	for start < len(outcomes) && now.Sub(outcomes[start].time) > s.window {
+		start++
+	}
+
+	if start == len(outcomes) {
+		delete(s.responses, podName)
+		return nil
+	}
+	outcomes = outcomes[start:]
+	s.responses[podName] = outcomes
+	return outcomes
+}
@@ -0,0 +1,148 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestGoodput_Score(t *testing.T) {
+	ctx := context.Background()
+
+	throughputPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "high-throughput", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	goodputPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "high-goodput", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	newPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "new", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	respond := func(s *scorer.Goodput, pod types.Pod, status string, count int) {
+		for range count {
+			s.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": status}}, pod.GetPod())
+		}
+	}
+
+	tests := []struct {
+		name       string
+		responses  func(s *scorer.Goodput)
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:      "no responses",
+			responses: func(_ *scorer.Goodput) {},
+			wantScores: map[types.Pod]float64{
+				throughputPod: 1.0,
+				goodputPod:    1.0,
+				newPod:        1.0,
+			},
+		},
+		{
+			name: "high throughput with errors scores below high goodput",
+			responses: func(s *scorer.Goodput) {
+				// 9 responses, only 2 successful
+				respond(s, throughputPod, "200", 2)
+				respond(s, throughputPod, "500", 5)
+				respond(s, throughputPod, "429", 2)
+				// 4 responses, all successful
+				respond(s, goodputPod, "200", 4)
+			},
+			wantScores: map[types.Pod]float64{
+				throughputPod: 0.3,
+				goodputPod:    1.0,
+				newPod:        1.0,
+			},
+		},
+		{
+			name: "lightly used pod without errors is not penalized",
+			responses: func(s *scorer.Goodput) {
+				respond(s, throughputPod, "200", 99)
+				respond(s, goodputPod, "200", 1)
+			},
+			wantScores: map[types.Pod]float64{
+				throughputPod: 1.0,
+				goodputPod:    1.0,
+				newPod:        1.0,
+			},
+		},
+		{
+			name: "only failures",
+			responses: func(s *scorer.Goodput) {
+				respond(s, throughputPod, "503", 3)
+			},
+			wantScores: map[types.Pod]float64{
+				throughputPod: 0.25,
+				goodputPod:    1.0,
+				newPod:        1.0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := scorer.NewGoodput(ctx, nil)
+			test.responses(s)
+
+			got := s.Score(ctx, nil, nil, []types.Pod{throughputPod, goodputPod, newPod})
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestGoodput_WindowExpiration(t *testing.T) {
+	ctx := context.Background()
+	s := scorer.NewGoodput(ctx, &scorer.GoodputParameters{Window: "100ms"})
+
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	s.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": "500"}}, pod.GetPod())
+
+	if got := s.Score(ctx, nil, nil, []types.Pod{pod})[pod]; got != 0.5 {
+		t.Errorf("Expected score 0.5 for a failing pod, got %v", got)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if got := s.Score(ctx, nil, nil, []types.Pod{pod})[pod]; got != 1.0 {
+		t.Errorf("Expected score 1 once failures left the window, got %v", got)
+	}
+}
+
+func TestGoodput_BoundedSamples(t *testing.T) {
+	ctx := context.Background()
+	s := scorer.NewGoodput(ctx, nil)
+
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	for range 1000 {
+		s.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": "500"}}, pod.GetPod())
+	}
+	// only the most recent outcomes are kept, so old failures are dropped
+	for range 1000 {
+		s.PostResponse(ctx, &types.LLMRequest{}, &requestcontrol.Response{Headers: map[string]string{":status": "200"}}, pod.GetPod())
+	}
+
+	if got := s.Score(ctx, nil, nil, []types.Pod{pod})[pod]; got != 1.0 {
+		t.Errorf("Expected score 1 once failures were dropped, got %v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// GPUHeadroomType is the type of the GPUHeadroom scorer.
+	GPUHeadroomType = "gpu-headroom-scorer"
+
+	// GPUMemoryLabelDefault is the default pod label holding the GPU memory of a pod
+	GPUMemoryLabelDefault = "llm-d.ai/gpu-mem"
+)
+
+type gpuHeadroomParameters struct {
+	// Label is the name of the pod label holding the GPU memory of the pod, in any unit used consistently across pods.
+	Label string `json:"label"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &GPUHeadroom{}
+
+// GPUHeadroomFactory defines the factory function for the GPUHeadroom scorer.
+func GPUHeadroomFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := gpuHeadroomParameters{Label: GPUMemoryLabelDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", GPUHeadroomType, err)
+		}
+	}
+
+	return NewGPUHeadroom(parameters.Label).WithName(name), nil
+}
+
+// NewGPUHeadroom creates a new GPUHeadroom scorer.
+// labelName - the name of the pod label holding the GPU memory of the pod
+func NewGPUHeadroom(labelName string) *GPUHeadroom {
+	return &GPUHeadroom{
+		typedName: plugins.TypedName{Type: GPUHeadroomType},
+		labelName: labelName,
+	}
+}
+
+// GPUHeadroom is a scorer that prefers pods with more free KV-cache memory in absolute terms.
+// In pools mixing GPU sizes, the KV-cache utilization alone doesn't capture the headroom of a
+// pod, e.g., a large GPU at 50% utilization has more room than a small one at 40%.
+// The absolute headroom of a pod is estimated as its GPU memory label times its free KV-cache
+// portion, i.e., 1 - KVCacheUsagePercent.
+type GPUHeadroom struct {
+	typedName plugins.TypedName
+	labelName string
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *GPUHeadroom) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *GPUHeadroom) WithName(name string) *GPUHeadroom {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods in range of 0-1 by their absolute headroom, normalized by the
+// largest headroom among the pods. Absolute headrooms can only be compared when known for all
+// pods, therefore if any pod lacks a valid GPU memory label, all pods are scored by their
+// relative headroom, i.e., their free KV-cache portion.
+func (s *GPUHeadroom) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+
+	gpuMemory := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		memory, ok := s.gpuMemory(ctx, pod)
+		if !ok {
+			for _, pod := range pods {
+				scoredPods[pod] = freeKVCache(pod)
+			}
+			return scoredPods
+		}
+		gpuMemory[pod] = memory
+	}
+
+	maxHeadroom := 0.0
+	for _, pod := range pods {
+		headroom := gpuMemory[pod] * freeKVCache(pod)
+		scoredPods[pod] = headroom
+		maxHeadroom = max(maxHeadroom, headroom)
+	}
+	for pod, headroom := range scoredPods {
+		if maxHeadroom > 0 {
+			scoredPods[pod] = headroom / maxHeadroom
+		}
+	}
+
+	return scoredPods
+}
+
+// gpuMemory returns the GPU memory of the given pod, and whether its label is set and valid.
+func (s *GPUHeadroom) gpuMemory(ctx context.Context, pod types.Pod) (float64, bool) {
+	value, found := pod.GetPod().Labels[s.labelName]
+	if !found {
+		return 0, false
+	}
+	memory, err := strconv.ParseFloat(value, 64)
+	if err != nil || memory <= 0 {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Ignoring invalid GPU memory label", "pod", pod.GetPod().NamespacedName, "value", value)
+		return 0, false
+	}
+	return memory, true
+}
+
+// freeKVCache returns the free portion of the KV-cache of the given pod, in range of 0-1.
+func freeKVCache(pod types.Pod) float64 {
+	return 1.0 - min(max(pod.GetMetrics().KVCacheUsagePercent, 0), 1)
+}
@@ -0,0 +1,73 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestGPUHeadroom_Score(t *testing.T) {
+	tests := []struct {
+		name       string
+		pods       []types.Pod
+		wantScores map[string]float64
+	}{
+		{
+			name: "larger absolute headroom wins despite higher utilization",
+			pods: []types.Pod{createPod("large", "", map[string]string{scorer.GPUMemoryLabelDefault: "80"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.5}), createPod("small", "", map[string]string{scorer.GPUMemoryLabelDefault: "24"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.4})},
+			wantScores: map[string]float64{
+				"large": 1.0,
+				"small": 0.36, // 24 * 0.6 / (80 * 0.5)
+			},
+		},
+		{
+			name: "same memory prefers lower utilization",
+			pods: []types.Pod{createPod("busy", "", map[string]string{scorer.GPUMemoryLabelDefault: "40"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.75}), createPod("idle", "", map[string]string{scorer.GPUMemoryLabelDefault: "40"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0})},
+			wantScores: map[string]float64{
+				"busy": 0.25,
+				"idle": 1.0,
+			},
+		},
+		{
+			name: "missing label falls back to relative headroom",
+			pods: []types.Pod{createPod("large", "", map[string]string{scorer.GPUMemoryLabelDefault: "80"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.5}), createPod("unlabeled", "", nil, backendmetrics.MetricsState{KVCacheUsagePercent: 0.4})},
+			wantScores: map[string]float64{
+				"large":     0.5,
+				"unlabeled": 0.6,
+			},
+		},
+		{
+			name: "invalid label falls back to relative headroom",
+			pods: []types.Pod{createPod("large", "", map[string]string{scorer.GPUMemoryLabelDefault: "80"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.5}), createPod("invalid", "", map[string]string{scorer.GPUMemoryLabelDefault: "lots"}, backendmetrics.MetricsState{KVCacheUsagePercent: 0.4})},
+			wantScores: map[string]float64{
+				"large":   0.5,
+				"invalid": 0.6,
+			},
+		},
+		{
+			name: "all pods full",
+			pods: []types.Pod{createPod("large", "", map[string]string{scorer.GPUMemoryLabelDefault: "80"}, backendmetrics.MetricsState{KVCacheUsagePercent: 1}), createPod("small", "", map[string]string{scorer.GPUMemoryLabelDefault: "24"}, backendmetrics.MetricsState{KVCacheUsagePercent: 1})},
+			wantScores: map[string]float64{
+				"large": 0,
+				"small": 0,
+			},
+		},
+	}
+
+	s := scorer.NewGPUHeadroom(scorer.GPUMemoryLabelDefault)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.Score(context.Background(), nil, nil, test.pods)
+
+			assert.Len(t, got, len(test.wantScores))
+			for pod, score := range got {
+				assert.InDelta(t, test.wantScores[pod.GetPod().NamespacedName.Name], score, 1e-9, pod.GetPod().NamespacedName.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// HybridPrefixCacheType is the type of the HybridPrefixCache scorer.
+	HybridPrefixCacheType = "hybrid-prefix-cache-scorer"
+)
+
+type hybridPrefixCacheParameters struct {
+	// PreciseScorer is the name of the precise prefix cache scorer plugin.
+	PreciseScorer string `json:"preciseScorer"`
+	// PrefixPluginName is the name of the prefix cache plugin whose state holds the estimated matches.
+	PrefixPluginName string `json:"prefixPluginName"`
+}
+
+// preciseIndex is the KV-cache index state the HybridPrefixCache scorer consults first.
+type preciseIndex interface {
+	// podScores returns the number of matching KV-blocks of the request per pod address, for the given pods.
+	podScores(ctx context.Context, request *types.LLMRequest, pods []types.Pod) (map[string]int, error)
+	// tracksPod returns true if the KV-cache state of the given pod is tracked by the index.
+	tracksPod(pod types.Pod) bool
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer = &HybridPrefixCache{}
+	_ preciseIndex     = &PrecisePrefixCacheScorer{}
+)
+
+// HybridPrefixCacheFactory defines the factory function for the HybridPrefixCache scorer.
+func HybridPrefixCacheFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := hybridPrefixCacheParameters{PrefixPluginName: prefix.PrefixCachePluginType}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", HybridPrefixCacheType, err)
+		}
+	}
+
+	precise, err := plugins.PluginByType[*PrecisePrefixCacheScorer](handle, parameters.PreciseScorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the precise scorer of the '%s' scorer - %w", HybridPrefixCacheType, err)
+	}
+
+	return NewHybridPrefixCache(precise, parameters.PrefixPluginName).WithName(name), nil
+}
+
+// NewHybridPrefixCache creates a new HybridPrefixCache scorer.
+// precise - the precise prefix cache scorer whose index is consulted first
+// prefixPluginName - the name of the prefix cache plugin whose state holds the estimated matches
+func NewHybridPrefixCache(precise *PrecisePrefixCacheScorer, prefixPluginName string) *HybridPrefixCache {
+	return newHybridPrefixCache(precise, prefixPluginName)
+}
+
+// newHybridPrefixCache creates a new HybridPrefixCache scorer over the given precise index.
+func newHybridPrefixCache(precise preciseIndex, prefixPluginName string) *HybridPrefixCache {
+	return &HybridPrefixCache{
+		typedName:             plugins.TypedName{Type: HybridPrefixCacheType},
+		precise:               precise,
+		prefixPluginTypedName: plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefixPluginName},
+	}
+}
+
+// HybridPrefixCache scores pods by prefix-cache locality, combining the precise KV-cache index with
+// the estimated prefix cache. Pods whose KV-cache state is tracked by the precise index, i.e. that
+// published KV-events, are scored by their precise matches. Pods that never published KV-events,
+// e.g. model servers not publishing them, fall back to their estimated matches, as computed by the
+// prefix cache plugin in the scheduling profile, instead of being considered cache-cold.
+// The prefix cache plugin must run before this scorer in the scheduling profile.
+type HybridPrefixCache struct {
+	typedName             plugins.TypedName
+	precise               preciseIndex
+	prefixPluginTypedName plugins.TypedName
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *HybridPrefixCache) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *HybridPrefixCache) WithName(name string) *HybridPrefixCache {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the pods tracked by the precise index by their precise matches, and the other pods by
+// their estimated matches. The matched blocks of all the pods are merged and normalized together, so
+// precise and estimated matches are on the same scale. The returned scores are in a range of 0-1.
+func (s *HybridPrefixCache) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	loggerDebug := log.FromContext(ctx).WithName(s.typedName.String()).V(logutil.DEBUG)
+	if request == nil {
+		loggerDebug.Info("Request is nil, skipping scoring")
+		return nil
+	}
+
+	precisePods := []types.Pod{}
+	estimatedPods := []types.Pod{}
+	for _, pod := range pods {
+		if s.precise.tracksPod(pod) {
+			precisePods = append(precisePods, pod)
+		} else {
+			estimatedPods = append(estimatedPods, pod)
+		}
+	}
+
+	// the matched blocks per pod address, holding all the pods so cold pods anchor the normalization
+	matches := make(map[string]int, len(pods))
+	for _, pod := range pods {
+		if address, ok := podAddress(pod); ok {
+			matches[address] = 0
+		}
+	}
+
+	if len(precisePods) > 0 {
+		scores, err := s.precise.podScores(ctx, request, precisePods)
+		if err != nil {
+			loggerDebug.Error(err, "Failed to get precise pod scores, falling back to the estimated scores")
+			estimatedPods = pods
+		} else {
+			for address, score := range scores {
+				matches[address] = score
+			}
+		}
+	}
+
+	if prefixState := s.prefixState(ctx, cycleState); prefixState != nil {
+		for _, pod := range estimatedPods {
+			if address, ok := podAddress(pod); ok {
+				matches[address] = prefixState.PrefixCacheServers[prefix.ServerID(pod.GetPod().NamespacedName)]
+			}
+		}
+	}
+
+	loggerDebug.Info("Scored pods", "precise", len(pods)-len(estimatedPods), "estimated", len(estimatedPods), "matches", matches)
+	if _, maxMatches := getMinMax(matches); maxMatches <= 0 {
+		// no pod holds any of the request's blocks
+		scoredPods := make(map[types.Pod]float64, len(pods))
+		for _, pod := range pods {
+			scoredPods[pod] = 0
+		}
+		return scoredPods
+	}
+	return indexedScoresToNormalizedScoredPods(pods, podAddress, matches)
+}
+
+// prefixState returns the state of the prefix cache plugin in the current scheduling cycle,
+// or nil if it is not available.
+func (s *HybridPrefixCache) prefixState(ctx context.Context, cycleState *types.CycleState) *prefix.SchedulingContextState {
+	if cycleState == nil {
+		return nil
+	}
+
+	prefixState, err := types.ReadCycleStateKey[*prefix.SchedulingContextState](cycleState, plugins.StateKey(s.prefixPluginTypedName.String()))
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Unable to read prefix state, ignoring estimated matches", "error", err)
+		return nil
+	}
+	return prefixState
+}
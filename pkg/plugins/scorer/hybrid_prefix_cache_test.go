@@ -0,0 +1,126 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+// fakePreciseIndex is a precise index holding fixed scores for the tracked pod addresses.
+type fakePreciseIndex struct {
+	tracked map[string]bool
+	scores  map[string]int
+	err     error
+}
+
+func (f *fakePreciseIndex) podScores(context.Context, *types.LLMRequest, []types.Pod) (map[string]int, error) {
+	return f.scores, f.err
+}
+
+func (f *fakePreciseIndex) tracksPod(pod types.Pod) bool {
+	return f.tracked[pod.GetPod().Address]
+}
+
+func TestHybridPrefixCache_Score(t *testing.T) {
+	preciseHot := createPod("precise-hot", "10.0.0.1", nil, backendmetrics.MetricsState{})
+	preciseCold := createPod("precise-cold", "10.0.0.2", nil, backendmetrics.MetricsState{})
+	estimatedHot := createPod("estimated-hot", "10.0.0.3", nil, backendmetrics.MetricsState{})
+	estimatedCold := createPod("estimated-cold", "10.0.0.4", nil, backendmetrics.MetricsState{})
+	pods := []types.Pod{preciseHot, preciseCold, estimatedHot, estimatedCold}
+
+	prefixStateKey := plugins.StateKey(plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefix.PrefixCachePluginType}.String())
+	newCycleState := func() *types.CycleState {
+		cycleState := types.NewCycleState()
+		cycleState.Write(prefixStateKey, &prefix.SchedulingContextState{
+			PrefixHashes: make([]prefix.BlockHash, 4),
+			PrefixCacheServers: map[prefix.ServerID]int{
+				// estimated matches of the precise pods are ignored
+				prefix.ServerID(preciseCold.GetPod().NamespacedName):  4,
+				prefix.ServerID(estimatedHot.GetPod().NamespacedName): 3,
+			},
+		})
+		return cycleState
+	}
+	tracked := map[string]bool{"10.0.0.1": true, "10.0.0.2": true}
+
+	tests := []struct {
+		name       string
+		precise    *fakePreciseIndex
+		cycleState *types.CycleState
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:       "precise and estimated pods are merged",
+			precise:    &fakePreciseIndex{tracked: tracked, scores: map[string]int{"10.0.0.1": 8, "10.0.0.2": 2}},
+			cycleState: newCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 1, preciseCold: 0.25, estimatedHot: 0.375, estimatedCold: 0},
+		},
+		{
+			name:       "tracked pods absent from the precise result are cold",
+			precise:    &fakePreciseIndex{tracked: tracked, scores: map[string]int{"10.0.0.1": 8}},
+			cycleState: newCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 1, preciseCold: 0, estimatedHot: 0.375, estimatedCold: 0},
+		},
+		{
+			name:       "precise failure falls back to the estimated scores",
+			precise:    &fakePreciseIndex{tracked: tracked, err: errors.New("tokenization failed")},
+			cycleState: newCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 0, preciseCold: 1, estimatedHot: 0.75, estimatedCold: 0},
+		},
+		{
+			name:       "missing prefix state scores estimated pods as cold",
+			precise:    &fakePreciseIndex{tracked: tracked, scores: map[string]int{"10.0.0.1": 8, "10.0.0.2": 2}},
+			cycleState: types.NewCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 1, preciseCold: 0.25, estimatedHot: 0, estimatedCold: 0},
+		},
+		{
+			name:       "no matches",
+			precise:    &fakePreciseIndex{tracked: tracked, scores: map[string]int{}},
+			cycleState: types.NewCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 0, preciseCold: 0, estimatedHot: 0, estimatedCold: 0},
+		},
+		{
+			name:       "no tracked pods",
+			precise:    &fakePreciseIndex{},
+			cycleState: newCycleState(),
+			wantScores: map[types.Pod]float64{preciseHot: 0, preciseCold: 1, estimatedHot: 0.75, estimatedCold: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scorer := newHybridPrefixCache(test.precise, prefix.PrefixCachePluginType)
+			got := scorer.Score(context.Background(), test.cycleState, &types.LLMRequest{RequestId: "req"}, pods)
+			assert.Equal(t, test.wantScores, got)
+		})
+	}
+}
+
+func TestHybridPrefixCache_ScoreMixedScale(t *testing.T) {
+	precisePod := createPod("precise", "10.0.0.1", nil, backendmetrics.MetricsState{})
+	estimatedPod := createPod("estimated", "10.0.0.2", nil, backendmetrics.MetricsState{})
+	coldPod := createPod("cold", "10.0.0.3", nil, backendmetrics.MetricsState{})
+
+	cycleState := types.NewCycleState()
+	cycleState.Write(plugins.StateKey(plugins.TypedName{Type: prefix.PrefixCachePluginType, Name: prefix.PrefixCachePluginType}.String()),
+		&prefix.SchedulingContextState{
+			PrefixHashes:       make([]prefix.BlockHash, 100),
+			PrefixCacheServers: map[prefix.ServerID]int{prefix.ServerID(estimatedPod.GetPod().NamespacedName): 90},
+		})
+
+	// a precise pod holding a single block of the request must not outrank an estimated pod holding most of them
+	precise := &fakePreciseIndex{tracked: map[string]bool{"10.0.0.1": true}, scores: map[string]int{"10.0.0.1": 1}}
+	scorer := newHybridPrefixCache(precise, prefix.PrefixCachePluginType)
+	got := scorer.Score(context.Background(), cycleState, &types.LLMRequest{RequestId: "req"},
+		[]types.Pod{precisePod, estimatedPod, coldPod})
+
+	assert.Equal(t, 1.0, got[estimatedPod])
+	assert.InDelta(t, 1.0/90, got[precisePod], 1e-9)
+	assert.Equal(t, 0.0, got[coldPod])
+}
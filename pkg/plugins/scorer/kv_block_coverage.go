@@ -0,0 +1,59 @@
+package scorer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache/kvblock"
+)
+
+// compile-time type assertion
+var _ kvblock.Index = &coverageTrackingIndex{}
+
+// coverageTrackingIndex wraps a KV-block index, counting the pod entries added to and
+// evicted from it by the KV-events. The count approximates the amount of data in the index,
+// as entries re-added by repeated events are counted again.
+// It also records the pods that published KV-events, i.e. whose KV-cache state is tracked.
+type coverageTrackingIndex struct {
+	kvblock.Index
+	entries atomic.Int64
+	pods    sync.Map
+}
+
+// newCoverageTrackingIndex creates a new coverage tracking index wrapping the given index.
+func newCoverageTrackingIndex(index kvblock.Index) *coverageTrackingIndex {
+	return &coverageTrackingIndex{Index: index}
+}
+
+// Add adds the keys and their pod entries to the wrapped index and counts them.
+func (i *coverageTrackingIndex) Add(ctx context.Context, keys []kvblock.Key, entries []kvblock.PodEntry) error {
+	if err := i.Index.Add(ctx, keys, entries); err != nil {
+		return err
+	}
+	i.entries.Add(int64(len(keys) * len(entries)))
+	for _, entry := range entries {
+		i.pods.Store(entry.PodIdentifier, struct{}{})
+	}
+	return nil
+}
+
+// Evict removes the pod entries of the key from the wrapped index and discounts them.
+func (i *coverageTrackingIndex) Evict(ctx context.Context, key kvblock.Key, entries []kvblock.PodEntry) error {
+	if err := i.Index.Evict(ctx, key, entries); err != nil {
+		return err
+	}
+	i.entries.Add(-int64(len(entries)))
+	return nil
+}
+
+// coverage returns the number of pod entries currently counted in the index.
+func (i *coverageTrackingIndex) coverage() int {
+	return int(max(i.entries.Load(), 0))
+}
+
+// tracksPod returns true if the pod with the given identifier published KV-events to the index.
+func (i *coverageTrackingIndex) tracksPod(podIdentifier string) bool {
+	_, found := i.pods.Load(podIdentifier)
+	return found
+}
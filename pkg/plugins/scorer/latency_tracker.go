@@ -0,0 +1,144 @@
+package scorer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	// defaultLatencyWindow defines the default sliding window over which latencies are kept.
+	defaultLatencyWindow = time.Minute
+
+	// maxPendingLatencies bounds the number of requests waiting for their response.
+	maxPendingLatencies = 10000
+	// maxLatencySamples bounds the number of latency samples kept per pod.
+	maxLatencySamples = 1000
+)
+
+// latencySample is a single latency observation
+type latencySample struct {
+	time    time.Time
+	latency time.Duration
+}
+
+// pendingRequest is a request waiting for its response
+type pendingRequest struct {
+	podName string
+	sent    time.Time
+}
+
+// latencyTracker measures, per pod, the latency between sending a request to the pod
+// (PreRequest) and receiving its response headers (PostResponse), and keeps the samples
+// observed within a sliding window. It is shared by the latency based scorers.
+type latencyTracker struct {
+	window time.Duration
+
+	// pending holds the requests waiting for their response, keyed by podName.requestID
+	pending *ttlcache.Cache[string, pendingRequest]
+
+	// samples holds the latency samples within the window per pod
+	samples map[string][]latencySample
+	mutex   *sync.Mutex
+}
+
+// newLatencyTracker creates a new latency tracker keeping samples within the given window.
+// Requests without a response for longer than requestTimeout are dropped.
+func newLatencyTracker(window time.Duration, requestTimeout time.Duration) *latencyTracker {
+	return &latencyTracker{
+		window: window,
+		pending: ttlcache.New[string, pendingRequest](
+			ttlcache.WithTTL[string, pendingRequest](requestTimeout),
+			ttlcache.WithCapacity[string, pendingRequest](maxPendingLatencies),
+			ttlcache.WithDisableTouchOnHit[string, pendingRequest](),
+		),
+		samples: make(map[string][]latencySample),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// requestSent records the send time of the given request to the pod selected by the primary profile.
+func (t *latencyTracker) requestSent(request *types.LLMRequest, schedulingResult *types.SchedulingResult) {
+	profileResult := schedulingResult.ProfileResults[schedulingResult.PrimaryProfileName]
+	if profileResult == nil || len(profileResult.TargetPods) == 0 {
+		return
+	}
+
+	entry := requestEntry{PodName: profileResult.TargetPods[0].GetPod().NamespacedName.String(), RequestID: request.RequestId}
+	t.pending.Set(entry.String(), pendingRequest{podName: entry.PodName, sent: time.Now()}, ttlcache.DefaultTTL)
+}
+
+// responseReceived records the latency of the given request on the pod that served it.
+// It returns the observed latency and whether the request's send time was known.
+func (t *latencyTracker) responseReceived(request *types.LLMRequest, targetPod *backend.Pod) (time.Duration, bool) {
+	if targetPod == nil {
+		return 0, false
+	}
+
+	entry := requestEntry{PodName: targetPod.NamespacedName.String(), RequestID: request.RequestId}
+	item, found := t.pending.GetAndDelete(entry.String())
+	if !found {
+		return 0, false
+	}
+
+	now := time.Now()
+	latency := now.Sub(item.Value().sent)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	samples := append(t.pruneLocked(entry.PodName, now), latencySample{time: now, latency: latency})
+	if len(samples) > maxLatencySamples {
+		samples = samples[len(samples)-maxLatencySamples:]
+	}
+	t.samples[entry.PodName] = samples
+	return latency, true
+}
+
+// latencies returns the latencies observed on the given pod within the window, oldest first.
+func (t *latencyTracker) latencies(podName string) []time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	samples := t.pruneLocked(podName, time.Now())
+	latencies := make([]time.Duration, len(samples))
+	for i, sample := range samples {
+		latencies[i] = sample.latency
+	}
+	return latencies
+}
+
+// oldestPending returns, per pod, the time the oldest request still waiting for its response was sent.
+// Pods without pending requests are not included.
+func (t *latencyTracker) oldestPending() map[string]time.Time {
+	oldest := make(map[string]time.Time)
+	t.pending.Range(func(item *ttlcache.Item[string, pendingRequest]) bool {
+		request := item.Value()
+		if sent, found := oldest[request.podName]; !found || request.sent.Before(sent) {
+			oldest[request.podName] = request.sent
+		}
+		return true
+	})
+	return oldest
+}
+
+// pruneLocked drops the samples of the given pod that are outside the window and returns
+// the remaining ones. The mutex must be held by the caller.
+func (t *latencyTracker) pruneLocked(podName string, now time.Time) []latencySample {
+	samples := t.samples[podName]
+	start := 0
+	for start < len(samples) && now.Sub(samples[start].time) > t.window {
+		start++
+	}
+
+	if start == len(samples) {
+		delete(t.samples, podName)
+		return nil
+	}
+	samples = samples[start:]
+	t.samples[podName] = samples
+	return samples
+}
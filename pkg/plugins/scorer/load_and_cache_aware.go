@@ -0,0 +1,103 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// LoadAndCacheAwareType is the type of the LoadAndCacheAware scorer
+	LoadAndCacheAwareType = "load-and-cache-aware-scorer"
+
+	// KVWeightDefault defines the default weight of the KV-cache usage
+	KVWeightDefault = 0.5
+)
+
+type loadAndCacheAwareParameters struct {
+	Threshold int     `json:"threshold"`
+	KVWeight  float64 `json:"kvWeight"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &LoadAndCacheAware{}
+
+// LoadAndCacheAwareFactory defines the factory function for the LoadAndCacheAware scorer
+func LoadAndCacheAwareFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := loadAndCacheAwareParameters{Threshold: QueueThresholdDefault, KVWeight: KVWeightDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", LoadAndCacheAwareType, err)
+		}
+	}
+
+	scorer, err := NewLoadAndCacheAware(handle.Context(), parameters.Threshold, parameters.KVWeight)
+	if err != nil {
+		return nil, err
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewLoadAndCacheAware creates a new scorer based on both load and KV-cache usage
+// queueThreshold - the waiting queue size at which the queue based score reaches 0
+// kvWeight - the weight, in range of 0-1, of the KV-cache usage; 0 means pure load, 1 means pure KV-cache usage
+func NewLoadAndCacheAware(ctx context.Context, queueThreshold int, kvWeight float64) (*LoadAndCacheAware, error) {
+	if kvWeight < 0 || kvWeight > 1 {
+		return nil, errors.New("kvWeight must be in range of 0-1")
+	}
+	if queueThreshold <= 0 {
+		log.FromContext(ctx).V(logutil.DEFAULT).Info(fmt.Sprintf("queueThreshold %d should be positive, using default queue threshold %d", queueThreshold, QueueThresholdDefault))
+		queueThreshold = QueueThresholdDefault
+	}
+
+	return &LoadAndCacheAware{
+		typedName:      plugins.TypedName{Type: LoadAndCacheAwareType},
+		queueThreshold: float64(queueThreshold),
+		kvWeight:       kvWeight,
+	}, nil
+}
+
+// LoadAndCacheAware scorer that is based on both load and KV-cache usage
+type LoadAndCacheAware struct {
+	typedName      plugins.TypedName
+	queueThreshold float64
+	kvWeight       float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *LoadAndCacheAware) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *LoadAndCacheAware) WithName(name string) *LoadAndCacheAware {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pod in range of 0-1
+// The queue based score is 1 for a pod with an empty waiting requests queue, and decreases linearly
+// to 0 for a pod with number of requests in the queue equal to the threshold.
+// The KV-cache based score is the free portion of the KV-cache, i.e., 1 - KVCacheUsagePercent.
+// The final score blends both, giving the KV-cache based score the configured weight.
+func (s *LoadAndCacheAware) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64)
+
+	for _, pod := range pods {
+		waitingRequests := min(float64(pod.GetMetrics().WaitingQueueSize), s.queueThreshold)
+		queueScore := 1.0 - (waitingRequests / s.queueThreshold)
+		kvScore := freeKVCache(pod)
+
+		scoredPods[pod] = (1-s.kvWeight)*queueScore + s.kvWeight*kvScore
+	}
+	return scoredPods
+}
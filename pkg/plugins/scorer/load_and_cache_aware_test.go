@@ -0,0 +1,103 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestLoadAndCacheAwareScorer(t *testing.T) {
+	// the queue favors pod-a, while the KV-cache usage favors pod-b
+	podA := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{
+			WaitingQueueSize:    0,
+			KVCacheUsagePercent: 0.75,
+		},
+	}
+	podB := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{
+			WaitingQueueSize:    5,
+			KVCacheUsagePercent: 0,
+		},
+	}
+	podC := &types.PodMetrics{
+		Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-c"}},
+		MetricsState: &backendmetrics.MetricsState{
+			WaitingQueueSize:    15,
+			KVCacheUsagePercent: 1,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		kvWeight   float64
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:     "pure load",
+			kvWeight: 0,
+			wantScores: map[types.Pod]float64{
+				podA: 1,
+				podB: 0.5,
+				podC: 0,
+			},
+		},
+		{
+			name:     "pure KV-cache",
+			kvWeight: 1,
+			wantScores: map[types.Pod]float64{
+				podA: 0.25,
+				podB: 1,
+				podC: 0,
+			},
+		},
+		{
+			name:     "even blend",
+			kvWeight: 0.5,
+			wantScores: map[types.Pod]float64{
+				podA: 0.625,
+				podB: 0.75,
+				podC: 0,
+			},
+		},
+		{
+			name:     "mostly load",
+			kvWeight: 0.25,
+			wantScores: map[types.Pod]float64{
+				podA: 0.8125,
+				podB: 0.625,
+				podC: 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := scorer.NewLoadAndCacheAware(context.Background(), 10, test.kvWeight)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := s.Score(context.Background(), nil, nil, []types.Pod{podA, podB, podC})
+
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestLoadAndCacheAwareScorer_InvalidKVWeight(t *testing.T) {
+	if _, err := scorer.NewLoadAndCacheAware(context.Background(), 10, 1.5); err == nil {
+		t.Error("Expected error for kvWeight above 1")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
@@ -18,10 +21,21 @@ const (
 
 	// QueueThresholdDefault defines the default queue threshold value
 	QueueThresholdDefault = 128
+
+	// LoadCurveLinear penalizes queued requests linearly
+	LoadCurveLinear = "linear"
+	// LoadCurveQuadratic penalizes queued requests quadratically
+	LoadCurveQuadratic = "quadratic"
+	// LoadCurveExponential penalizes queued requests exponentially
+	LoadCurveExponential = "exponential"
+
+	// exponentialCurveSteepness defines how sharply the exponential curve rises towards the threshold
+	exponentialCurveSteepness = 5.0
 )
 
 type loadAwareParameters struct {
-	Threshold int `json:"threshold"`
+	Threshold int    `json:"threshold"`
+	Curve     string `json:"curve"`
 }
 
 // compile-time type assertion
@@ -31,12 +45,35 @@ var _ framework.Scorer = &LoadAware{}
 func LoadAwareFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
 	parameters := loadAwareParameters{Threshold: QueueThresholdDefault}
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", LoadAwareType, err)
 		}
 	}
 
-	return NewLoadAware(handle.Context(), parameters.Threshold).WithName(name), nil
+	scorer, err := NewLoadAware(handle.Context(), parameters.Threshold).WithCurve(parameters.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", LoadAwareType, err)
+	}
+
+	return scorer.WithName(name), nil
+}
+
+// loadCurve returns the penalty function of the given curve. The penalty function maps the
+// queue fill ratio, in range of 0-1, to a penalty in range of 0-1.
+func loadCurve(curve string) (func(float64) float64, error) {
+	switch curve {
+	case "", LoadCurveLinear:
+		return func(ratio float64) float64 { return ratio }, nil
+	case LoadCurveQuadratic:
+		return func(ratio float64) float64 { return ratio * ratio }, nil
+	case LoadCurveExponential:
+		return func(ratio float64) float64 {
+			return math.Expm1(exponentialCurveSteepness*ratio) / math.Expm1(exponentialCurveSteepness)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown curve '%s', expected one of '%s', '%s' or '%s'",
+			curve, LoadCurveLinear, LoadCurveQuadratic, LoadCurveExponential)
+	}
 }
 
 // NewLoadAware creates a new load based scorer
@@ -46,9 +83,11 @@ func NewLoadAware(ctx context.Context, queueThreshold int) *LoadAware {
 		log.FromContext(ctx).V(logutil.DEFAULT).Info(fmt.Sprintf("queueThreshold %d should be positive, using default queue threshold %d", queueThreshold, QueueThresholdDefault))
 	}
 
+	curve, _ := loadCurve(LoadCurveLinear)
 	return &LoadAware{
 		typedName:      plugins.TypedName{Type: LoadAwareType},
 		queueThreshold: float64(queueThreshold),
+		curve:          curve,
 	}
 }
 
@@ -56,6 +95,8 @@ func NewLoadAware(ctx context.Context, queueThreshold int) *LoadAware {
 type LoadAware struct {
 	typedName      plugins.TypedName
 	queueThreshold float64
+	// curve maps the queue fill ratio to the penalty applied to the score
+	curve func(float64) float64
 }
 
 // TypedName returns the typed name of the plugin.
@@ -69,11 +110,22 @@ func (s *LoadAware) WithName(name string) *LoadAware {
 	return s
 }
 
+// WithCurve sets the penalty curve of the scorer, one of LoadCurveLinear, LoadCurveQuadratic or LoadCurveExponential.
+// An empty curve means LoadCurveLinear.
+func (s *LoadAware) WithCurve(curve string) (*LoadAware, error) {
+	penalty, err := loadCurve(curve)
+	if err != nil {
+		return nil, err
+	}
+	s.curve = penalty
+	return s, nil
+}
+
 // Score scores the given pod in range of 0-1
 // Currently metrics contains number of requests waiting in the queue, there is no information about number of requests
 // that can be processed in the given pod immediately.
 // Pod with empty waiting requests queue is scored with 0.5
-// Pod with requests in the queue will get score between 0.5 and 0.
+// Pod with requests in the queue will get score between 0.5 and 0, shaped by the configured curve.
 // Score 0 will get pod with number of requests in the queue equal to the threshold used in load-based filter
 // In the future, pods with additional capacity will get score higher than 0.5
 func (s *LoadAware) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
@@ -88,7 +140,7 @@ func (s *LoadAware) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRe
 			if waitingRequests > s.queueThreshold {
 				waitingRequests = s.queueThreshold
 			}
-			scoredPods[pod] = 0.5 * (1.0 - (waitingRequests / s.queueThreshold))
+			scoredPods[pod] = 0.5 * (1.0 - s.curve(waitingRequests/s.queueThreshold))
 		}
 	}
 	return scoredPods
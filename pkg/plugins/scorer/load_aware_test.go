@@ -2,13 +2,16 @@ package scorer_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics" // Import config for thresholds
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 
@@ -69,3 +72,67 @@ func TestLoadBasedScorer(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBasedScorerCurves(t *testing.T) {
+	empty := createPod("empty", "", nil, backendmetrics.MetricsState{})
+	light := createPod("light", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 2})
+	heavy := createPod("heavy", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 8})
+	full := createPod("full", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 10})
+	overloaded := createPod("overloaded", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 15})
+	pods := []types.Pod{empty, light, heavy, full, overloaded}
+
+	linear, err := scorer.NewLoadAware(context.Background(), 10).WithCurve(scorer.LoadCurveLinear)
+	assert.NoError(t, err)
+	linearScores := linear.Score(context.Background(), nil, nil, pods)
+
+	for _, curve := range []string{scorer.LoadCurveLinear, scorer.LoadCurveQuadratic, scorer.LoadCurveExponential} {
+		t.Run(curve, func(t *testing.T) {
+			s, err := scorer.NewLoadAware(context.Background(), 10).WithCurve(curve)
+			assert.NoError(t, err)
+
+			got := s.Score(context.Background(), nil, nil, pods)
+
+			assert.Equal(t, 0.5, got[empty])
+			assert.InDelta(t, 0, got[full], 1e-9)
+			assert.InDelta(t, 0, got[overloaded], 1e-9)
+			assert.Greater(t, got[light], got[heavy])
+			assert.Greater(t, got[heavy], got[full])
+
+			if curve != scorer.LoadCurveLinear {
+				// convex curves barely penalize a short queue and strongly penalize a near threshold one
+				assert.Greater(t, got[light], linearScores[light])
+				assert.Greater(t, got[heavy], linearScores[heavy])
+			}
+		})
+	}
+
+	t.Run("empty curve defaults to linear", func(t *testing.T) {
+		s, err := scorer.NewLoadAware(context.Background(), 10).WithCurve("")
+		assert.NoError(t, err)
+		assert.Equal(t, linearScores, s.Score(context.Background(), nil, nil, pods))
+	})
+}
+
+func TestLoadAwareFactoryInvalidCurve(t *testing.T) {
+	rawParameters := json.RawMessage(`{"threshold": 10, "curve": "logarithmic"}`)
+	_, err := scorer.LoadAwareFactory("load", rawParameters, plugins.NewEppHandle(context.Background()))
+	assert.ErrorContains(t, err, "unknown curve 'logarithmic'")
+}
+
+func TestLoadAwareFactoryMisspelledParameter(t *testing.T) {
+	rawParameters := json.RawMessage(`{"treshold": 10}`)
+	_, err := scorer.LoadAwareFactory("load", rawParameters, plugins.NewEppHandle(context.Background()))
+	assert.ErrorContains(t, err, `unknown field "treshold"`)
+}
+
+// createPod creates a pod in the default namespace with the given address, labels and metrics.
+func createPod(name string, address string, labels map[string]string, metrics backendmetrics.MetricsState) *types.PodMetrics {
+	return &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+			Address:        address,
+			Labels:         labels,
+		},
+		MetricsState: &metrics,
+	}
+}
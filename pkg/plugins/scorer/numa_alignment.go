@@ -0,0 +1,104 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// NUMAAlignmentType is the type of the NUMAAlignment scorer.
+	NUMAAlignmentType = "numa-alignment-scorer"
+
+	// NUMAAlignmentLabelDefault is the default pod label holding the NUMA alignment of a pod
+	NUMAAlignmentLabelDefault = "llm-d.ai/numa-alignment"
+)
+
+type numaAlignmentParameters struct {
+	// Label is the name of the pod label holding the NUMA alignment, a value in range of 0-1.
+	Label string `json:"label"`
+	// MinPromptLength is the minimal prompt length (in bytes) for which NUMA alignment is considered.
+	MinPromptLength int `json:"minPromptLength"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &NUMAAlignment{}
+
+// NUMAAlignmentFactory defines the factory function for the NUMAAlignment scorer.
+func NUMAAlignmentFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := numaAlignmentParameters{Label: NUMAAlignmentLabelDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", NUMAAlignmentType, err)
+		}
+	}
+
+	return NewNUMAAlignment(parameters.Label, parameters.MinPromptLength).WithName(name), nil
+}
+
+// NewNUMAAlignment creates a new NUMAAlignment scorer.
+// labelName - the name of the pod label holding the NUMA alignment
+// minPromptLength - requests with shorter prompts score all pods equally
+func NewNUMAAlignment(labelName string, minPromptLength int) *NUMAAlignment {
+	return &NUMAAlignment{
+		typedName:       plugins.TypedName{Type: NUMAAlignmentType},
+		labelName:       labelName,
+		minPromptLength: minPromptLength,
+	}
+}
+
+// NUMAAlignment is a scorer that prefers pods whose GPU is well aligned (NUMA-wise) with the
+// host memory used for offloading KV-cache. Transfer cost matters mostly for large contexts,
+// therefore the scorer only differentiates between pods for prompts that are long enough.
+type NUMAAlignment struct {
+	typedName       plugins.TypedName
+	labelName       string
+	minPromptLength int
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *NUMAAlignment) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *NUMAAlignment) WithName(name string) *NUMAAlignment {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by their NUMA alignment label. Pods without a valid
+// label are scored with 0. Short prompts get score 0 for all pods.
+func (s *NUMAAlignment) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	longPrompt := request != nil && len(request.Prompt) >= s.minPromptLength
+
+	for _, pod := range pods {
+		scoredPods[pod] = 0.0
+		if !longPrompt {
+			continue
+		}
+
+		value, found := pod.GetPod().Labels[s.labelName]
+		if !found {
+			continue
+		}
+		alignment, err := strconv.ParseFloat(value, 64)
+		if err != nil || alignment < 0 || alignment > 1 {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Ignoring invalid NUMA alignment label", "pod", pod.GetPod().NamespacedName, "value", value)
+			continue
+		}
+		scoredPods[pod] = alignment
+	}
+
+	return scoredPods
+}
@@ -0,0 +1,82 @@
+package scorer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestNUMAAlignment_Score(t *testing.T) {
+	alignedPod := &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Name: "aligned"},
+			Labels:         map[string]string{scorer.NUMAAlignmentLabelDefault: "1"},
+		},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	partialPod := &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Name: "partial"},
+			Labels:         map[string]string{scorer.NUMAAlignmentLabelDefault: "0.5"},
+		},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	invalidPod := &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Name: "invalid"},
+			Labels:         map[string]string{scorer.NUMAAlignmentLabelDefault: "yes"},
+		},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	unlabeledPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "unlabeled"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{alignedPod, partialPod, invalidPod, unlabeledPod}
+
+	tests := []struct {
+		name       string
+		req        *types.LLMRequest
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name: "large context prefers aligned pods",
+			req:  &types.LLMRequest{Prompt: strings.Repeat("a", 100)},
+			wantScores: map[types.Pod]float64{
+				alignedPod:   1.0,
+				partialPod:   0.5,
+				invalidPod:   0.0,
+				unlabeledPod: 0.0,
+			},
+		},
+		{
+			name: "short prompt scores all pods equally",
+			req:  &types.LLMRequest{Prompt: "short"},
+			wantScores: map[types.Pod]float64{
+				alignedPod:   0.0,
+				partialPod:   0.0,
+				invalidPod:   0.0,
+				unlabeledPod: 0.0,
+			},
+		},
+	}
+
+	s := scorer.NewNUMAAlignment(scorer.NUMAAlignmentLabelDefault, 50)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.Score(context.Background(), nil, test.req, pods)
+
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
@@ -3,23 +3,41 @@ package scorer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache"
+	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache/kvblock"
 	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache/kvevents"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
 	// PrecisePrefixCachePluginType is the type-name of the PrecisePrefixCacheScorer plugin.
 	PrecisePrefixCachePluginType = "precise-prefix-cache-scorer"
+
+	// defaultInitBackoff is the default backoff before the first retry of the indexer creation.
+	defaultInitBackoff = time.Second
 )
 
+// podScorer scores pods by the KV-blocks of a prompt they hold, as implemented by `kvcache.Indexer`.
+type podScorer interface {
+	// GetPodScores returns the number of matching KV-blocks of the prompt per pod, restricted to
+	// the given pod identifiers, or all pods if none are given.
+	GetPodScores(ctx context.Context, prompt, modelName string, podIdentifiers []string) (map[string]int, error)
+}
+
+// newKVCacheIndexer creates the `kvcache.Indexer`, replaceable in tests.
+var newKVCacheIndexer = kvcache.NewKVCacheIndexer
+
 // PrecisePrefixCachePluginConfig holds the configuration for the
 // PrecisePrefixCacheScorer plugin.
 type PrecisePrefixCachePluginConfig struct {
@@ -30,10 +48,37 @@ type PrecisePrefixCachePluginConfig struct {
 	// used to subscribe to KV-cache events and update the internal KV-cache
 	// index state.
 	KVEventsConfig *kvevents.Config `json:"kvEventsConfig"`
+	// KVEventsConfigs holds the configurations of multiple `kvevents.Pool`s,
+	// one per ZMQ endpoint, all updating the same KV-cache index state, e.g.
+	// when the prefill and decode pods publish KV-events on separate
+	// endpoints. When set, KVEventsConfig is ignored. Fields omitted from a
+	// configuration, other than the endpoint, take their default values.
+	KVEventsConfigs []*kvevents.Config `json:"kvEventsConfigs"`
+	// CoverageThreshold is the number of KV-block entries indexed from the KV-events
+	// at which the index is considered ready. Until then, the scores are down-weighted
+	// in proportion to the coverage. When 0, the scores are never down-weighted and the
+	// index is considered ready once any entry is indexed.
+	CoverageThreshold int `json:"coverageThreshold"`
+	// PromptHeader is the name of a request header holding the key to score against
+	// instead of the prompt, e.g. a canonicalized prompt pre-computed by the gateway.
+	// When empty, or the header is not set on a request, the prompt is used.
+	PromptHeader string `json:"promptHeader"`
+	// MaxInitRetries is the number of times the creation of the `kvcache.Indexer`
+	// is retried when it fails, e.g. while its dependencies are briefly unavailable
+	// during a rolling restart. When 0, the creation is not retried.
+	MaxInitRetries int `json:"maxInitRetries"`
+	// InitBackoff is the backoff before the first retry of the indexer creation,
+	// doubled on every further retry.
+	// This field accepts duration strings like "500ms", "1s".
+	InitBackoff string `json:"initBackoff"`
 }
 
-// compile-time type assertion
-var _ framework.Scorer = &PrecisePrefixCacheScorer{}
+// compile-time type assertions
+var (
+	_ framework.Scorer     = &PrecisePrefixCacheScorer{}
+	_ DataCoverageReporter = &PrecisePrefixCacheScorer{}
+	_ podScorer            = &kvcache.Indexer{}
+)
 
 // PrecisePrefixCachePluginFactory defines the factory function for creating
 // a new instance of the PrefixCacheTrackingPlugin.
@@ -50,7 +95,7 @@ func PrecisePrefixCachePluginFactory(name string, rawParameters json.RawMessage,
 	}
 
 	if rawParameters != nil {
-		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
 			return nil, fmt.Errorf("failed to parse %s plugin config: %w", PrecisePrefixCachePluginType, err)
 		}
 	}
@@ -73,32 +118,126 @@ func PrecisePrefixCachePluginFactory(name string, rawParameters json.RawMessage,
 // If the configuration is invalid or if the indexer fails to initialize,
 // an error is returned.
 func New(ctx context.Context, config PrecisePrefixCachePluginConfig) (*PrecisePrefixCacheScorer, error) {
+	if config.CoverageThreshold < 0 {
+		return nil, fmt.Errorf("invalid coverageThreshold %d, must not be negative", config.CoverageThreshold)
+	}
+	if config.MaxInitRetries < 0 {
+		return nil, fmt.Errorf("invalid maxInitRetries %d, must not be negative", config.MaxInitRetries)
+	}
+	kvEventsConfigs, err := config.kvEventsConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	initBackoff := defaultInitBackoff
+	if config.InitBackoff != "" {
+		if backoff, err := time.ParseDuration(config.InitBackoff); err != nil || backoff <= 0 {
+			log.FromContext(ctx).Error(err, "Invalid init backoff duration, using default init backoff", "initBackoff", config.InitBackoff)
+		} else {
+			initBackoff = backoff
+		}
+	}
+
 	// initialize the indexer
-	kvCacheIndexer, err := kvcache.NewKVCacheIndexer(ctx, config.IndexerConfig)
+	kvCacheIndexer, err := createWithRetries(ctx, config.MaxInitRetries, initBackoff, func() (*kvcache.Indexer, error) {
+		return newKVCacheIndexer(ctx, config.IndexerConfig)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create `kvcache.Indexer`: %w", err)
 	}
 
 	go kvCacheIndexer.Run(ctx)
 
-	// initialize the KV-events pool
-	pool := kvevents.NewPool(config.KVEventsConfig, kvCacheIndexer.KVBlockIndex())
-	pool.Start(ctx)
+	// initialize the KV-events pools, tracking the coverage of the index they update
+	kvBlockIndex := newCoverageTrackingIndex(kvCacheIndexer.KVBlockIndex())
+	startKVEventsPools(ctx, kvEventsConfigs, kvBlockIndex)
 
 	return &PrecisePrefixCacheScorer{
-		typedName:      plugins.TypedName{Type: PrecisePrefixCachePluginType},
-		kvCacheIndexer: kvCacheIndexer,
+		typedName:         plugins.TypedName{Type: PrecisePrefixCachePluginType},
+		kvCacheIndexer:    kvCacheIndexer,
+		kvBlockIndex:      kvBlockIndex,
+		coverageThreshold: config.CoverageThreshold,
+		promptHeader:      config.PromptHeader,
 	}, nil
 }
 
+// createWithRetries calls the given create function, retrying up to maxRetries times while it
+// fails, with a backoff doubled on every retry. The last error is returned once the retries are
+// exhausted, or the context's error if it is done while backing off.
+func createWithRetries[T any](ctx context.Context, maxRetries int, backoff time.Duration, create func() (T, error)) (T, error) {
+	created, err := create()
+	for retry := 1; err != nil && retry <= maxRetries; retry++ {
+		log.FromContext(ctx).Error(err, "Failed to create, retrying", "retry", retry, "maxRetries", maxRetries, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return created, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		created, err = create()
+	}
+	return created, err
+}
+
+// kvEventsConfigs returns the configurations of the KV-events pools to start, completing
+// omitted fields with their default values. An error is returned if an endpoint is missing
+// or configured more than once.
+func (c PrecisePrefixCachePluginConfig) kvEventsConfigs() ([]*kvevents.Config, error) {
+	if len(c.KVEventsConfigs) == 0 {
+		return []*kvevents.Config{c.KVEventsConfig}, nil
+	}
+
+	defaults := kvevents.DefaultConfig()
+	kvEventsConfigs := make([]*kvevents.Config, 0, len(c.KVEventsConfigs))
+	endpoints := make(map[string]struct{}, len(c.KVEventsConfigs))
+	for _, kvEventsConfig := range c.KVEventsConfigs {
+		if kvEventsConfig == nil || kvEventsConfig.ZMQEndpoint == "" {
+			return nil, errors.New("invalid kvEventsConfigs, zmqEndpoint must be set")
+		}
+		if _, found := endpoints[kvEventsConfig.ZMQEndpoint]; found {
+			return nil, fmt.Errorf("invalid kvEventsConfigs, zmqEndpoint '%s' is configured more than once", kvEventsConfig.ZMQEndpoint)
+		}
+		endpoints[kvEventsConfig.ZMQEndpoint] = struct{}{}
+
+		completed := *kvEventsConfig
+		if completed.TopicFilter == "" {
+			completed.TopicFilter = defaults.TopicFilter
+		}
+		if completed.Concurrency <= 0 {
+			completed.Concurrency = defaults.Concurrency
+		}
+		kvEventsConfigs = append(kvEventsConfigs, &completed)
+	}
+	return kvEventsConfigs, nil
+}
+
+// startKVEventsPools starts a `kvevents.Pool` for each of the given configurations,
+// all updating the given index.
+func startKVEventsPools(ctx context.Context, kvEventsConfigs []*kvevents.Config, index kvblock.Index) []*kvevents.Pool {
+	pools := make([]*kvevents.Pool, 0, len(kvEventsConfigs))
+	for _, kvEventsConfig := range kvEventsConfigs {
+		pool := kvevents.NewPool(kvEventsConfig, index)
+		pool.Start(ctx)
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
 // PrecisePrefixCacheScorer implements the framework.Scorer interface.
 // The scorer implements precise prefix-cache KV-block locality scoring.
 // It uses the `kvcache.Indexer` to score pods based on the KV-cache index
 // state, and the `kvevents.Pool` to subscribe to KV-cache events
 // to keep the internal KV-cache index state up-to-date.
+//
+// Since a fresh index has no data, which is indistinguishable from no
+// locality, the scorer reports the coverage of its index and may
+// down-weight its scores until the coverage crosses a threshold.
 type PrecisePrefixCacheScorer struct {
-	typedName      plugins.TypedName
-	kvCacheIndexer *kvcache.Indexer
+	typedName         plugins.TypedName
+	kvCacheIndexer    podScorer
+	kvBlockIndex      *coverageTrackingIndex
+	coverageThreshold int
+	promptHeader      string
 }
 
 // TypedName returns the typed name of the plugin.
@@ -112,8 +251,29 @@ func (s *PrecisePrefixCacheScorer) WithName(name string) *PrecisePrefixCacheScor
 	return s
 }
 
-// Score scores the provided pod based on the KVCache index state.
-// The returned scores are normalized to a range of 0-1.
+// DataCoverage returns the number of KV-block entries currently indexed from the KV-events.
+func (s *PrecisePrefixCacheScorer) DataCoverage() int {
+	return s.kvBlockIndex.coverage()
+}
+
+// IndexReady returns true if the coverage of the index reached the coverage threshold,
+// or if any entry is indexed when no threshold is set.
+func (s *PrecisePrefixCacheScorer) IndexReady() bool {
+	return s.DataCoverage() >= max(s.coverageThreshold, 1)
+}
+
+// coverageWeight returns the weight, in range of 0-1, given to the scores according to the coverage.
+func (s *PrecisePrefixCacheScorer) coverageWeight() float64 {
+	if s.coverageThreshold == 0 {
+		return 1.0
+	}
+	return min(float64(s.DataCoverage())/float64(s.coverageThreshold), 1.0)
+}
+
+// Score scores the provided pod based on the KVCache index state, keyed off the
+// prompt header if configured and set, or the prompt otherwise.
+// The returned scores are normalized to a range of 0-1, and down-weighted
+// while the coverage of the index is below the coverage threshold.
 func (s *PrecisePrefixCacheScorer) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
 	loggerDebug := log.FromContext(ctx).WithName(s.typedName.String()).V(logutil.DEBUG)
 	if request == nil {
@@ -121,21 +281,68 @@ func (s *PrecisePrefixCacheScorer) Score(ctx context.Context, _ *types.CycleStat
 		return nil
 	}
 
-	scores, err := s.kvCacheIndexer.GetPodScores(ctx, request.Prompt, request.TargetModel, nil)
+	if len(pods) == 0 {
+		return map[types.Pod]float64{}
+	}
+
+	scores, err := s.podScores(ctx, request, pods)
 	if err != nil {
 		loggerDebug.Error(err, "Failed to get pod scores")
 		return nil
 	}
 	loggerDebug.Info("Got pod scores", "scores", scores)
 
-	podToKey := func(pod types.Pod) (string, bool) {
-		metricsPod := pod.GetPod()
-		if metricsPod == nil {
-			return "", false
+	scoredPods := indexedScoresToNormalizedScoredPods(pods, podAddress, scores)
+	if weight := s.coverageWeight(); weight < 1 {
+		loggerDebug.Info("Down-weighting scores until the index is ready", "coverage", s.DataCoverage(), "weight", weight)
+		for pod, score := range scoredPods {
+			scoredPods[pod] = score * weight
 		}
+	}
+	return scoredPods
+}
+
+// podScores returns the number of matching KV-blocks of the request per pod address, for the
+// given pods holding any of the request's KV-blocks. The index lookup is restricted to the
+// addresses of the given pods, and other pods are dropped from its result, so they never
+// skew the normalization of the scores.
+func (s *PrecisePrefixCacheScorer) podScores(ctx context.Context, request *types.LLMRequest, pods []types.Pod) (map[string]int, error) {
+	addresses := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		if address, ok := podAddress(pod); ok {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return map[string]int{}, nil
+	}
+
+	scores, err := s.kvCacheIndexer.GetPodScores(ctx, scoringPrompt(request, s.promptHeader), request.TargetModel, addresses)
+	if err != nil {
+		return nil, err
+	}
+	candidateScores := make(map[string]int, len(addresses))
+	for _, address := range addresses {
+		if score, found := scores[address]; found {
+			candidateScores[address] = score
+		}
+	}
+	return candidateScores, nil
+}
+
+// tracksPod returns true if the KV-cache state of the given pod is tracked by the index,
+// i.e. the pod published KV-events.
+func (s *PrecisePrefixCacheScorer) tracksPod(pod types.Pod) bool {
+	address, ok := podAddress(pod)
+	return ok && s.kvBlockIndex.tracksPod(address)
+}
 
-		return metricsPod.Address, true
+// podAddress returns the address of the given pod, which identifies the pod in the KV-cache index.
+func podAddress(pod types.Pod) (string, bool) {
+	metricsPod := pod.GetPod()
+	if metricsPod == nil {
+		return "", false
 	}
 
-	return indexedScoresToNormalizedScoredPods(pods, podToKey, scores)
+	return metricsPod.Address, true
 }
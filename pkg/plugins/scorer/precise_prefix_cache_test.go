@@ -0,0 +1,263 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache"
+	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache/kvblock"
+	"github.com/llm-d/llm-d-kv-cache-manager/pkg/kvcache/kvevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"k8s.io/apimachinery/pkg/util/sets"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestPrecisePrefixCacheScorer_IndexReady(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		coverageThreshold int
+		// wantWeights holds the expected coverage weight initially, and after each event
+		wantWeights []float64
+		wantReady   []bool
+	}{
+		{
+			name:              "no threshold",
+			coverageThreshold: 0,
+			wantWeights:       []float64{1, 1, 1, 1},
+			wantReady:         []bool{false, true, true, true},
+		},
+		{
+			name:              "with threshold",
+			coverageThreshold: 4,
+			wantWeights:       []float64{0, 0.5, 1, 0.75},
+			wantReady:         []bool{false, false, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index, err := kvblock.NewInMemoryIndex(&kvblock.InMemoryIndexConfig{Size: 100, PodCacheSize: 10})
+			require.NoError(t, err)
+			scorer := &PrecisePrefixCacheScorer{
+				kvBlockIndex:      newCoverageTrackingIndex(index),
+				coverageThreshold: test.coverageThreshold,
+			}
+
+			keys := []kvblock.Key{{ModelName: "model", ChunkHash: 1}, {ModelName: "model", ChunkHash: 2}}
+			podEntries := []kvblock.PodEntry{{PodIdentifier: "10.0.0.1", DeviceTier: "gpu"}}
+			events := []func(){
+				func() { require.NoError(t, scorer.kvBlockIndex.Add(ctx, keys, podEntries)) },
+				func() {
+					require.NoError(t, scorer.kvBlockIndex.Add(ctx, keys, []kvblock.PodEntry{{PodIdentifier: "10.0.0.2", DeviceTier: "gpu"}}))
+				},
+				func() { require.NoError(t, scorer.kvBlockIndex.Evict(ctx, keys[0], podEntries)) },
+			}
+
+			assert.Equal(t, 0, scorer.DataCoverage())
+			assert.Equal(t, test.wantReady[0], scorer.IndexReady())
+			assert.Equal(t, test.wantWeights[0], scorer.coverageWeight())
+
+			for i, event := range events {
+				event()
+				assert.Equal(t, test.wantReady[i+1], scorer.IndexReady(), "after event %d", i)
+				assert.Equal(t, test.wantWeights[i+1], scorer.coverageWeight(), "after event %d", i)
+			}
+			assert.Equal(t, 3, scorer.DataCoverage())
+			assert.True(t, scorer.kvBlockIndex.tracksPod("10.0.0.1"), "pods stay tracked after evictions")
+			assert.True(t, scorer.kvBlockIndex.tracksPod("10.0.0.2"))
+			assert.False(t, scorer.kvBlockIndex.tracksPod("10.0.0.3"))
+		})
+	}
+}
+
+// mockPodScorer is a pod scorer returning fixed scores and recording the pod identifiers it is called with.
+type mockPodScorer struct {
+	scores         map[string]int
+	calls          int
+	podIdentifiers []string
+}
+
+func (m *mockPodScorer) GetPodScores(_ context.Context, _, _ string, podIdentifiers []string) (map[string]int, error) {
+	m.calls++
+	m.podIdentifiers = podIdentifiers
+	return m.scores, nil
+}
+
+func TestPrecisePrefixCacheScorer_Score(t *testing.T) {
+	ctx := context.Background()
+	podA := createPod("pod-a", "10.0.0.1", nil, backendmetrics.MetricsState{})
+	podB := createPod("pod-b", "10.0.0.2", nil, backendmetrics.MetricsState{})
+	request := &types.LLMRequest{TargetModel: "model", Prompt: "hello"}
+
+	// the index holds a non-candidate pod with the best score
+	indexer := &mockPodScorer{scores: map[string]int{"10.0.0.1": 4, "10.0.0.2": 2, "10.0.0.9": 8}}
+	scorer := &PrecisePrefixCacheScorer{kvCacheIndexer: indexer}
+
+	got := scorer.Score(ctx, nil, request, []types.Pod{podA, podB})
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, indexer.podIdentifiers, "candidate addresses are forwarded")
+	assert.Equal(t, map[types.Pod]float64{podA: 1, podB: 0}, got, "non-candidate pods are not scored")
+
+	got = scorer.Score(ctx, nil, request, []types.Pod{})
+	assert.Empty(t, got)
+	assert.Equal(t, 1, indexer.calls, "no candidates skip the index lookup")
+}
+
+func TestPrecisePrefixCachePluginConfig_KVEventsConfigs(t *testing.T) {
+	single := &kvevents.Config{ZMQEndpoint: "tcp://*:5557", TopicFilter: "kv@", Concurrency: 4}
+
+	configs, err := PrecisePrefixCachePluginConfig{KVEventsConfig: single}.kvEventsConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, []*kvevents.Config{single}, configs)
+
+	configs, err = PrecisePrefixCachePluginConfig{
+		KVEventsConfig: single,
+		KVEventsConfigs: []*kvevents.Config{
+			{ZMQEndpoint: "tcp://*:5557"},
+			{ZMQEndpoint: "tcp://*:5558", TopicFilter: "decode@", Concurrency: 2},
+		},
+	}.kvEventsConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, []*kvevents.Config{
+		{ZMQEndpoint: "tcp://*:5557", TopicFilter: "kv@", Concurrency: 4},
+		{ZMQEndpoint: "tcp://*:5558", TopicFilter: "decode@", Concurrency: 2},
+	}, configs)
+
+	for _, invalid := range [][]*kvevents.Config{
+		{{ZMQEndpoint: "tcp://*:5557"}, {TopicFilter: "kv@"}},
+		{{ZMQEndpoint: "tcp://*:5557"}, nil},
+		{{ZMQEndpoint: "tcp://*:5557"}, {ZMQEndpoint: "tcp://*:5557"}},
+	} {
+		_, err := PrecisePrefixCachePluginConfig{KVEventsConfigs: invalid}.kvEventsConfigs()
+		assert.Error(t, err)
+	}
+}
+
+func TestStartKVEventsPools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	index, err := kvblock.NewInMemoryIndex(&kvblock.InMemoryIndexConfig{Size: 100, PodCacheSize: 10})
+	require.NoError(t, err)
+	kvBlockIndex := newCoverageTrackingIndex(index)
+
+	// fake endpoints of the prefill and decode pods, the events are injected into the pools directly
+	pools := startKVEventsPools(ctx, []*kvevents.Config{
+		{ZMQEndpoint: "inproc://prefill-kv-events", TopicFilter: "kv@", Concurrency: 1},
+		{ZMQEndpoint: "inproc://decode-kv-events", TopicFilter: "kv@", Concurrency: 1},
+	}, kvBlockIndex)
+	require.Len(t, pools, 2)
+	defer func() {
+		for _, pool := range pools {
+			pool.Shutdown(ctx)
+		}
+	}()
+
+	blockStored := func(podIdentifier string, blockHash uint64) *kvevents.Message {
+		rawEvent, err := msgpack.Marshal(kvevents.BlockStored{
+			BlockHashes: []any{blockHash},
+			TokenIds:    []uint32{1, 2, 3, 4},
+			BlockSize:   4,
+		}.ToTaggedUnion())
+		require.NoError(t, err)
+		payload, err := msgpack.Marshal(&kvevents.EventBatch{Events: []msgpack.RawMessage{rawEvent}})
+		require.NoError(t, err)
+		return &kvevents.Message{
+			Topic:         "kv@" + podIdentifier + "@model",
+			Payload:       payload,
+			PodIdentifier: podIdentifier,
+			ModelName:     "model",
+		}
+	}
+	pools[0].AddTask(blockStored("10.0.0.1", 1))
+	pools[1].AddTask(blockStored("10.0.0.2", 2))
+
+	keys := []kvblock.Key{{ModelName: "model", ChunkHash: 1}, {ModelName: "model", ChunkHash: 2}}
+	assert.Eventually(t, func() bool {
+		pods, err := index.Lookup(ctx, keys[:1], sets.New[string]())
+		return err == nil && assert.ObjectsAreEqual([]string{"10.0.0.1"}, pods[keys[0]]) && kvBlockIndex.coverage() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pods, err := index.Lookup(ctx, keys[1:], sets.New[string]())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2"}, pods[keys[1]])
+}
+
+func TestScoringPrompt(t *testing.T) {
+	request := &types.LLMRequest{
+		Prompt:  "raw prompt",
+		Headers: map[string]string{"x-routing-key": "canonical prompt"},
+	}
+
+	assert.Equal(t, "canonical prompt", scoringPrompt(request, "x-routing-key"))
+	assert.Equal(t, "raw prompt", scoringPrompt(request, "x-missing"))
+	assert.Equal(t, "raw prompt", scoringPrompt(request, ""))
+	assert.Equal(t, "raw prompt", scoringPrompt(&types.LLMRequest{Prompt: "raw prompt"}, "x-routing-key"))
+}
+
+func TestCreateWithRetries(t *testing.T) {
+	// stubCreate fails the given number of times before succeeding
+	stubCreate := func(failures int) (func() (string, error), *int) {
+		calls := 0
+		return func() (string, error) {
+			calls++
+			if calls <= failures {
+				return "", fmt.Errorf("attempt %d failed", calls)
+			}
+			return "indexer", nil
+		}, &calls
+	}
+	ctx := context.Background()
+
+	create, calls := stubCreate(2)
+	created, err := createWithRetries(ctx, 3, time.Millisecond, create)
+	require.NoError(t, err)
+	assert.Equal(t, "indexer", created)
+	assert.Equal(t, 3, *calls, "should succeed on the third attempt")
+
+	create, calls = stubCreate(2)
+	_, err = createWithRetries(ctx, 1, time.Millisecond, create)
+	assert.EqualError(t, err, "attempt 2 failed", "should return the last error once retries are exhausted")
+	assert.Equal(t, 2, *calls)
+
+	create, calls = stubCreate(2)
+	_, err = createWithRetries(ctx, 0, time.Millisecond, create)
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls, "should not retry by default")
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	create, calls = stubCreate(2)
+	_, err = createWithRetries(cancelledCtx, 3, time.Minute, create)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestNew_RetriesIndexerCreation(t *testing.T) {
+	originalNewKVCacheIndexer := newKVCacheIndexer
+	defer func() { newKVCacheIndexer = originalNewKVCacheIndexer }()
+
+	calls := 0
+	newKVCacheIndexer = func(context.Context, *kvcache.Config) (*kvcache.Indexer, error) {
+		calls++
+		return nil, errors.New("tokenizer unavailable")
+	}
+
+	_, err := New(context.Background(), PrecisePrefixCachePluginConfig{
+		KVEventsConfig: kvevents.DefaultConfig(),
+		MaxInitRetries: 2,
+		InitBackoff:    "1ms",
+	})
+	assert.ErrorContains(t, err, "tokenizer unavailable")
+	assert.Equal(t, 3, calls)
+
+	_, err = New(context.Background(), PrecisePrefixCachePluginConfig{MaxInitRetries: -1})
+	assert.Error(t, err)
+}
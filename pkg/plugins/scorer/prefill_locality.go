@@ -0,0 +1,113 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/profile"
+)
+
+const (
+	// PrefillLocalityType is the type of the PrefillLocality scorer.
+	PrefillLocalityType = "prefill-locality-scorer"
+
+	// NodeLabelDefault is the default pod label holding the node of a pod
+	NodeLabelDefault = "llm-d.ai/node"
+	// RackLabelDefault is the default pod label holding the rack of a pod
+	RackLabelDefault = "llm-d.ai/rack"
+)
+
+type prefillLocalityParameters struct {
+	// Labels are the names of the pod labels holding the location of a pod, from the closest to the
+	// farthest, e.g., node and then rack.
+	Labels []string `json:"labels"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &PrefillLocality{}
+
+// PrefillLocalityFactory defines the factory function for the PrefillLocality scorer.
+func PrefillLocalityFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := prefillLocalityParameters{Labels: []string{NodeLabelDefault, RackLabelDefault}}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", PrefillLocalityType, err)
+		}
+	}
+
+	scorer, err := NewPrefillLocality(parameters.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", PrefillLocalityType, err)
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewPrefillLocality creates a new PrefillLocality scorer.
+// labelNames - the names of the pod labels holding the location of a pod, from the closest to the farthest
+func NewPrefillLocality(labelNames []string) (*PrefillLocality, error) {
+	if len(labelNames) == 0 {
+		return nil, errors.New("labels must not be empty")
+	}
+
+	return &PrefillLocality{
+		typedName:  plugins.TypedName{Type: PrefillLocalityType},
+		labelNames: labelNames,
+	}, nil
+}
+
+// PrefillLocality is a decode scorer that prefers decode pods located close to the prefill pod selected
+// for the request, e.g., on the same node or rack, to minimize the KV-cache transfer latency. It requires
+// the PD profile handler to run prefill first, which provides the selected prefill pod in the cycle state.
+type PrefillLocality struct {
+	typedName  plugins.TypedName
+	labelNames []string
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *PrefillLocality) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *PrefillLocality) WithName(name string) *PrefillLocality {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by their closeness to the selected prefill pod. With N location labels,
+// a pod sharing the value of the i-th (0-based) label with the prefill pod is scored with (N-i)/N, using
+// the closest shared label. Pods sharing no location, and all pods when no prefill pod was selected,
+// are scored with 0.
+func (s *PrefillLocality) Score(ctx context.Context, cycleState *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 0.0
+	}
+
+	prefillResult, err := types.ReadCycleStateKey[*profile.PrefillResultState](cycleState, profile.PrefillResultStateKey)
+	if err != nil || len(prefillResult.TargetPods) == 0 {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("No prefill pod selected, scoring all pods equally")
+		return scoredPods
+	}
+	prefillLabels := prefillResult.TargetPods[0].GetPod().Labels
+
+	for _, pod := range pods {
+		for i, labelName := range s.labelNames {
+			location, found := prefillLabels[labelName]
+			if found && location != "" && pod.GetPod().Labels[labelName] == location {
+				scoredPods[pod] = float64(len(s.labelNames)-i) / float64(len(s.labelNames))
+				break
+			}
+		}
+	}
+	return scoredPods
+}
@@ -0,0 +1,72 @@
+package scorer_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/profile"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestPrefillLocality_Score(t *testing.T) {
+	prefillPod := createPod("prefill", "", map[string]string{scorer.NodeLabelDefault: "node-1", scorer.RackLabelDefault: "rack-1"}, backendmetrics.MetricsState{})
+	sameNode := createPod("same-node", "", map[string]string{scorer.NodeLabelDefault: "node-1", scorer.RackLabelDefault: "rack-1"}, backendmetrics.MetricsState{})
+	sameRack := createPod("same-rack", "", map[string]string{scorer.NodeLabelDefault: "node-2", scorer.RackLabelDefault: "rack-1"}, backendmetrics.MetricsState{})
+	remote := createPod("remote", "", map[string]string{scorer.NodeLabelDefault: "node-3", scorer.RackLabelDefault: "rack-2"}, backendmetrics.MetricsState{})
+	unlabeled := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "unlabeled"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{sameNode, sameRack, remote, unlabeled}
+
+	s, err := scorer.NewPrefillLocality([]string{scorer.NodeLabelDefault, scorer.RackLabelDefault})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		prefill    []types.Pod
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:       "closest location wins",
+			prefill:    []types.Pod{prefillPod},
+			wantScores: map[types.Pod]float64{sameNode: 1, sameRack: 0.5, remote: 0, unlabeled: 0},
+		},
+		{
+			name:       "no prefill pod scores all pods equally",
+			wantScores: map[types.Pod]float64{sameNode: 0, sameRack: 0, remote: 0, unlabeled: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cycleState := types.NewCycleState()
+			if test.prefill != nil {
+				cycleState.Write(profile.PrefillResultStateKey, &profile.PrefillResultState{TargetPods: test.prefill})
+			}
+
+			got := s.Score(context.Background(), cycleState, nil, pods)
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestPrefillLocalityFactory(t *testing.T) {
+	plugin, err := scorer.PrefillLocalityFactory("locality", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, scorer.PrefillLocalityType, plugin.TypedName().Type)
+
+	_, err = scorer.PrefillLocalityFactory("locality", json.RawMessage(`{"labels": []}`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// PromptClusterType is the type of the PromptCluster scorer.
+	PromptClusterType = "prompt-cluster-scorer"
+
+	defaultClusterPrefixLength = 256
+	defaultClusterQueueLimit   = 16
+	defaultClusterTimeout      = 5 * time.Minute
+	defaultMaxClusters         = 10000
+)
+
+// PromptClusterParameters defines the parameters for the PromptCluster scorer.
+type PromptClusterParameters struct {
+	// PrefixLength is the number of leading prompt characters used to bucket prompts into clusters.
+	PrefixLength int `json:"prefixLength"`
+	// QueueLimit is the waiting queue size at which the pod serving a cluster is considered
+	// loaded, causing requests of the cluster to spill to other pods.
+	QueueLimit int `json:"queueLimit"`
+	// ClusterTimeout defines how long a cluster is kept after its last request.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	ClusterTimeout string `json:"clusterTimeout"`
+	// MaxClusters is the maximal number of tracked clusters; the least recently used are dropped first.
+	MaxClusters int `json:"maxClusters"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer          = &PromptCluster{}
+	_ requestcontrol.PreRequest = &PromptCluster{}
+)
+
+// PromptClusterFactory defines the factory function for the PromptCluster scorer.
+func PromptClusterFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := PromptClusterParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", PromptClusterType, err)
+		}
+	}
+
+	return NewPromptCluster(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewPromptCluster creates a new PromptCluster scorer.
+func NewPromptCluster(ctx context.Context, params *PromptClusterParameters) *PromptCluster {
+	logger := log.FromContext(ctx)
+	prefixLength := defaultClusterPrefixLength
+	queueLimit := defaultClusterQueueLimit
+	clusterTimeout := defaultClusterTimeout
+	maxClusters := defaultMaxClusters
+
+	if params != nil {
+		if params.PrefixLength > 0 {
+			prefixLength = params.PrefixLength
+		}
+		if params.QueueLimit > 0 {
+			queueLimit = params.QueueLimit
+		}
+		if params.MaxClusters > 0 {
+			maxClusters = params.MaxClusters
+		}
+		if params.ClusterTimeout != "" {
+			paramsClusterTimeout, err := time.ParseDuration(params.ClusterTimeout)
+			if err != nil || paramsClusterTimeout <= 0 {
+				logger.Error(err, "Invalid cluster timeout duration, using default cluster timeout")
+			} else {
+				clusterTimeout = paramsClusterTimeout
+			}
+		}
+	}
+
+	return &PromptCluster{
+		typedName:    plugins.TypedName{Type: PromptClusterType},
+		prefixLength: prefixLength,
+		queueLimit:   queueLimit,
+		clusters: ttlcache.New[uint64, string](
+			ttlcache.WithTTL[uint64, string](clusterTimeout),
+			ttlcache.WithCapacity[uint64, string](uint64(maxClusters)),
+			ttlcache.WithDisableTouchOnHit[uint64, string](),
+		),
+	}
+}
+
+// PromptCluster is a scorer that groups recent prompts into clusters by a hash of their
+// leading characters and prefers the pod currently serving the request's cluster. Routing
+// similar prompts to the same pod improves prefix sharing within a batch. Once the serving
+// pod's waiting queue reaches the queue limit, the cluster spills: all pods are scored
+// equally and the next chosen pod takes over the cluster.
+type PromptCluster struct {
+	typedName    plugins.TypedName
+	prefixLength int
+	queueLimit   int
+
+	// clusters maps a cluster key to the name of the pod serving it
+	clusters *ttlcache.Cache[uint64, string]
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *PromptCluster) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *PromptCluster) WithName(name string) *PromptCluster {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the pod serving the request's cluster with 1 as long as it is not loaded,
+// all other pods are scored with 0.
+func (s *PromptCluster) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 0.0
+	}
+	if request == nil {
+		return scoredPods
+	}
+
+	item := s.clusters.Get(s.clusterKey(request))
+	if item == nil {
+		return scoredPods
+	}
+
+	for _, pod := range pods {
+		if pod.GetPod().NamespacedName.String() != item.Value() {
+			continue
+		}
+		if pod.GetMetrics().WaitingQueueSize >= s.queueLimit {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Cluster pod is loaded, spilling", "pod", item.Value())
+		} else {
+			scoredPods[pod] = 1.0
+		}
+		break
+	}
+
+	return scoredPods
+}
+
+// PreRequest records the pod chosen by the primary profile as the pod serving the request's cluster.
+func (s *PromptCluster) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	profileResult := schedulingResult.ProfileResults[schedulingResult.PrimaryProfileName]
+	if profileResult == nil || len(profileResult.TargetPods) == 0 {
+		return
+	}
+
+	podName := profileResult.TargetPods[0].GetPod().NamespacedName.String()
+	s.clusters.Set(s.clusterKey(request), podName, ttlcache.DefaultTTL)
+	log.FromContext(ctx).V(logutil.TRACE).Info("Recorded cluster pod", "pod", podName)
+}
+
+// clusterKey returns the cluster key of the given request, based on its target model
+// and the leading characters of its prompt.
+func (s *PromptCluster) clusterKey(request *types.LLMRequest) uint64 {
+	prompt := request.Prompt
+	if len(prompt) > s.prefixLength {
+		prompt = prompt[:s.prefixLength]
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(request.TargetModel))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(prompt))
+	return h.Sum64()
+}
@@ -0,0 +1,75 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestPromptCluster_ConvergeAndSpill(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{podA, podB}
+
+	schedulingResult := func(pod types.Pod) *types.SchedulingResult {
+		return &types.SchedulingResult{
+			PrimaryProfileName: "default",
+			ProfileResults: map[string]*types.ProfileRunResult{
+				"default": {TargetPods: []types.Pod{pod}},
+			},
+		}
+	}
+
+	ctx := context.Background()
+	s := scorer.NewPromptCluster(ctx, &scorer.PromptClusterParameters{PrefixLength: 16, QueueLimit: 4})
+
+	similar1 := &types.LLMRequest{TargetModel: "model", Prompt: "You are a helpful assistant. What is the weather?"}
+	similar2 := &types.LLMRequest{TargetModel: "model", Prompt: "You are a helpful assistant. Summarize this text."}
+	different := &types.LLMRequest{TargetModel: "model", Prompt: "Translate the following sentence."}
+
+	// unknown cluster, all pods are scored equally
+	got := s.Score(ctx, nil, similar1, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a new cluster (-want +got): %v", diff)
+	}
+	s.PreRequest(ctx, similar1, schedulingResult(podA), 0)
+
+	// similar prompts converge on the pod serving the cluster
+	got = s.Score(ctx, nil, similar2, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 1, podB: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a similar prompt (-want +got): %v", diff)
+	}
+
+	// prompts of another cluster are not affected
+	got = s.Score(ctx, nil, different, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a different prompt (-want +got): %v", diff)
+	}
+
+	// once the pod serving the cluster is loaded, the cluster spills
+	podA.MetricsState.WaitingQueueSize = 4
+	got = s.Score(ctx, nil, similar2, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a loaded cluster pod (-want +got): %v", diff)
+	}
+	s.PreRequest(ctx, similar2, schedulingResult(podB), 0)
+
+	// the cluster now converges on the new pod
+	got = s.Score(ctx, nil, similar1, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 1}, got); diff != "" {
+		t.Errorf("Unexpected output after spilling (-want +got): %v", diff)
+	}
+}
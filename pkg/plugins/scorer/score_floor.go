@@ -0,0 +1,96 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// ScoreFloorType is the type of the ScoreFloor scorer.
+	ScoreFloorType = "score-floor-scorer"
+)
+
+type scoreFloorParameters struct {
+	// Scorer is the name of the scorer plugin whose scores are raised.
+	Scorer string `json:"scorer"`
+	// Floor is the minimal score given to a pod, in range of 0-1.
+	Floor float64 `json:"floor"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &ScoreFloor{}
+
+// ScoreFloorFactory defines the factory function for the ScoreFloor scorer.
+func ScoreFloorFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := scoreFloorParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", ScoreFloorType, err)
+		}
+	}
+
+	scorer, err := plugins.PluginByType[framework.Scorer](handle, parameters.Scorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the raised scorer of the '%s' scorer - %w", ScoreFloorType, err)
+	}
+
+	floored, err := NewScoreFloor(scorer, parameters.Floor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", ScoreFloorType, err)
+	}
+	return floored.WithName(name), nil
+}
+
+// NewScoreFloor creates a new ScoreFloor scorer.
+// scorer - the scorer whose scores are raised
+// floor - the minimal score given to a pod, in range of 0-1 (exclusive)
+func NewScoreFloor(scorer framework.Scorer, floor float64) (*ScoreFloor, error) {
+	if floor < 0 || floor >= 1 {
+		return nil, errors.New("floor must be in range of 0-1, excluding 1")
+	}
+
+	return &ScoreFloor{
+		typedName: plugins.TypedName{Type: ScoreFloorType},
+		scorer:    scorer,
+		floor:     floor,
+	}, nil
+}
+
+// ScoreFloor is a scorer that linearly raises the scores of another scorer from range 0-1 into
+// range floor-1. Scorers such as session affinity or prefix cache scorers give 0 to non-matching
+// pods, so that a single matching pod keeps winning even when overloaded. Raising their scores
+// narrows the gap between the preferred pod and the rest, letting a load scorer in the same
+// profile spread traffic under contention, while a preferred pod that isn't overloaded still wins.
+type ScoreFloor struct {
+	typedName plugins.TypedName
+	scorer    framework.Scorer
+	floor     float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *ScoreFloor) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *ScoreFloor) WithName(name string) *ScoreFloor {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by raising the scores of the wrapped scorer into range floor-1.
+func (s *ScoreFloor) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := s.scorer.Score(ctx, cycleState, request, pods)
+	for pod, score := range scoredPods {
+		scoredPods[pod] = s.floor + (1-s.floor)*min(max(score, 0), 1)
+	}
+	return scoredPods
+}
@@ -0,0 +1,42 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestScoreFloor_Score(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	inner := &fixedScorer{scores: map[string]float64{"pod-a": 1, "pod-b": 0}}
+
+	s, err := scorer.NewScoreFloor(inner, 0.5)
+	require.NoError(t, err)
+	got := s.Score(context.Background(), nil, nil, []types.Pod{podA, podB})
+	assert.Equal(t, map[types.Pod]float64{podA: 1, podB: 0.5}, got)
+
+	s, err = scorer.NewScoreFloor(inner, 0)
+	require.NoError(t, err)
+	got = s.Score(context.Background(), nil, nil, []types.Pod{podA, podB})
+	assert.Equal(t, map[types.Pod]float64{podA: 1, podB: 0}, got, "zero floor keeps the scores")
+
+	_, err = scorer.NewScoreFloor(inner, 1)
+	assert.Error(t, err)
+	_, err = scorer.NewScoreFloor(inner, -0.1)
+	assert.Error(t, err)
+}
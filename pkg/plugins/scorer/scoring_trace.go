@@ -0,0 +1,226 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/profile"
+)
+
+const (
+	// ScoringTraceType is the type of the ScoringTrace plugin.
+	ScoringTraceType = "scoring-trace"
+	// TracedType is the type of the Traced scorer.
+	TracedType = "traced-scorer"
+
+	// ScoringTraceRequestHeader is the request header enabling the scoring trace, when set to "true".
+	ScoringTraceRequestHeader = "x-debug-scoring"
+	// ScoringTraceResponseHeader is the response header holding the scores of the pod that served the request.
+	ScoringTraceResponseHeader = "x-scoring-trace"
+
+	// scoringTraceTimeout bounds how long the trace of a request is kept until its response
+	scoringTraceTimeout = 5 * time.Minute
+	// maxPendingScoringTraces bounds the number of requests whose trace is kept until their response
+	maxPendingScoringTraces = 10000
+)
+
+type tracedParameters struct {
+	// Scorer is the name of the scorer plugin whose scores are traced.
+	Scorer string `json:"scorer"`
+	// Trace is the name of the scoring trace plugin collecting the scores.
+	Trace string `json:"trace"`
+}
+
+// compile-time type assertions
+var (
+	_ requestcontrol.PostResponse = &ScoringTrace{}
+	_ framework.Scorer            = &Traced{}
+)
+
+// ScoringTraceFactory defines the factory function for the ScoringTrace plugin.
+func ScoringTraceFactory(name string, _ json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	return NewScoringTrace().WithName(name), nil
+}
+
+// NewScoringTrace creates a new ScoringTrace plugin.
+func NewScoringTrace() *ScoringTrace {
+	return &ScoringTrace{
+		typedName: plugins.TypedName{Type: ScoringTraceType},
+		traces: ttlcache.New[string, *requestTrace](
+			ttlcache.WithTTL[string, *requestTrace](scoringTraceTimeout),
+			ttlcache.WithCapacity[string, *requestTrace](maxPendingScoringTraces),
+			ttlcache.WithDisableTouchOnHit[string, *requestTrace](),
+		),
+	}
+}
+
+// requestTrace holds the scores of each traced scorer per pod, for a single request.
+type requestTrace struct {
+	mutex sync.Mutex
+	// scores maps pod names to the scores given to the pod by each traced scorer
+	scores map[string]map[string]float64
+}
+
+// ScoringTrace collects the scores given by the traced scorers to each pod, for requests opting
+// in with the ScoringTraceRequestHeader, and returns the scores of the pod that served a request
+// in the ScoringTraceResponseHeader, to explain the routing decision.
+// Requests not opting in are not traced.
+type ScoringTrace struct {
+	typedName plugins.TypedName
+	// traces holds the trace of the requests being traced, keyed by request ID
+	traces *ttlcache.Cache[string, *requestTrace]
+}
+
+// TypedName returns the typed name of the plugin.
+func (t *ScoringTrace) TypedName() plugins.TypedName {
+	return t.typedName
+}
+
+// WithName sets the name of the plugin.
+func (t *ScoringTrace) WithName(name string) *ScoringTrace {
+	t.typedName.Name = name
+	return t
+}
+
+// enabled returns true if the given request opted in for the scoring trace.
+func (t *ScoringTrace) enabled(request *types.LLMRequest) bool {
+	return request != nil && request.Headers[ScoringTraceRequestHeader] == "true"
+}
+
+// record adds the scores given by the named scorer to the trace of the given request.
+func (t *ScoringTrace) record(request *types.LLMRequest, scorerName string, scores map[types.Pod]float64) {
+	item, _ := t.traces.GetOrSet(request.RequestId, &requestTrace{scores: map[string]map[string]float64{}})
+	trace := item.Value()
+
+	trace.mutex.Lock()
+	defer trace.mutex.Unlock()
+	for pod, score := range scores {
+		podName := pod.GetPod().NamespacedName.String()
+		if trace.scores[podName] == nil {
+			trace.scores[podName] = map[string]float64{}
+		}
+		trace.scores[podName][scorerName] = score
+	}
+}
+
+// PostResponse sets the scores given to the pod that served the request by each traced scorer,
+// as a JSON object keyed by scorer name, in a response header. It is a no-op for requests that
+// were not traced.
+func (t *ScoringTrace) PostResponse(ctx context.Context, request *types.LLMRequest, response *requestcontrol.Response, targetPod *backend.Pod) {
+	item, found := t.traces.GetAndDelete(request.RequestId)
+	if !found || targetPod == nil || response.Headers == nil {
+		return
+	}
+	trace := item.Value()
+
+	trace.mutex.Lock()
+	serialized, err := json.Marshal(trace.scores[targetPod.NamespacedName.String()])
+	trace.mutex.Unlock()
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Failed to serialize the scoring trace", "error", err)
+		return
+	}
+	response.Headers[ScoringTraceResponseHeader] = string(serialized)
+}
+
+// TracedFactory defines the factory function for the Traced scorer.
+func TracedFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := tracedParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", TracedType, err)
+		}
+	}
+
+	scorer, err := plugins.PluginByType[framework.Scorer](handle, parameters.Scorer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the traced scorer of the '%s' scorer - %w", TracedType, err)
+	}
+	trace, err := plugins.PluginByType[*ScoringTrace](handle, parameters.Trace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the scoring trace of the '%s' scorer - %w", TracedType, err)
+	}
+
+	return NewTraced(scorer, trace).WithName(name), nil
+}
+
+// NewTraced creates a new Traced scorer recording the scores of the given scorer in the given trace.
+func NewTraced(scorer framework.Scorer, trace *ScoringTrace) *Traced {
+	return &Traced{
+		typedName: plugins.TypedName{Type: TracedType},
+		scorer:    scorer,
+		trace:     trace,
+	}
+}
+
+// Traced is a scorer returning the scores of another scorer unchanged, while recording them,
+// under the name (or type, if unnamed) of the other scorer, in the scoring trace of requests opting in.
+type Traced struct {
+	typedName plugins.TypedName
+	scorer    framework.Scorer
+	trace     *ScoringTrace
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Traced) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *Traced) WithName(name string) *Traced {
+	s.typedName.Name = name
+	return s
+}
+
+// Score returns the scores of the traced scorer, recording them in the cycle state, for the
+// scheduling decision log, and in the trace if the request opted in.
+func (s *Traced) Score(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scores := s.scorer.Score(ctx, cycleState, request, pods)
+	recordScorerScores(cycleState, s.scorerName(), scores)
+	if s.trace.enabled(request) {
+		s.trace.record(request, s.scorerName(), scores)
+	}
+	return scores
+}
+
+// scorerName returns the name the scores of the traced scorer are recorded under, which is its
+// name, or its type if it is unnamed.
+func (s *Traced) scorerName() string {
+	if name := s.scorer.TypedName().Name; name != "" {
+		return name
+	}
+	return s.scorer.TypedName().Type
+}
+
+// recordScorerScores adds the scores given by the named scorer to the scorer scores of the cycle state.
+// The profiles of a scheduling cycle run one after the other, so the state is not updated concurrently.
+func recordScorerScores(cycleState *types.CycleState, scorerName string, scores map[types.Pod]float64) {
+	if cycleState == nil {
+		return
+	}
+	state, err := types.ReadCycleStateKey[*profile.ScorerScoresState](cycleState, profile.ScorerScoresStateKey)
+	if err != nil {
+		state = &profile.ScorerScoresState{Scores: map[string]map[string]float64{}}
+		cycleState.Write(profile.ScorerScoresStateKey, state)
+	}
+	for pod, score := range scores {
+		podName := pod.GetPod().NamespacedName.String()
+		if state.Scores[podName] == nil {
+			state.Scores[podName] = map[string]float64{}
+		}
+		state.Scores[podName][scorerName] = score
+	}
+}
@@ -0,0 +1,63 @@
+package scorer_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestScoringTrace(t *testing.T) {
+	ctx := context.Background()
+
+	podA := createPod("pod-a", "", nil, backendmetrics.MetricsState{})
+	podB := createPod("pod-b", "", nil, backendmetrics.MetricsState{WaitingQueueSize: 1})
+	pods := []types.Pod{podA, podB}
+
+	trace := scorer.NewScoringTrace().WithName("trace")
+	counting := &countingScorer{}
+	tracedScorers := []*scorer.Traced{
+		scorer.NewTraced(counting, trace),
+		scorer.NewTraced(scorer.NewLoadAware(ctx, 10).WithName("load"), trace),
+	}
+
+	schedule := func(request *types.LLMRequest) {
+		for _, traced := range tracedScorers {
+			traced.Score(ctx, nil, request, pods)
+		}
+	}
+
+	t.Run("traced request gets the scores of the serving pod", func(t *testing.T) {
+		request := &types.LLMRequest{RequestId: "traced", Headers: map[string]string{scorer.ScoringTraceRequestHeader: "true"}}
+		schedule(request)
+
+		response := &requestcontrol.Response{Headers: map[string]string{}}
+		trace.PostResponse(ctx, request, response, podB.GetPod())
+
+		require.Contains(t, response.Headers, scorer.ScoringTraceResponseHeader)
+		got := map[string]float64{}
+		require.NoError(t, json.Unmarshal([]byte(response.Headers[scorer.ScoringTraceResponseHeader]), &got))
+		assert.Equal(t, map[string]float64{"counting": 0.5, "load": 0.45}, got)
+	})
+
+	t.Run("untraced request gets no trace", func(t *testing.T) {
+		request := &types.LLMRequest{RequestId: "untraced", Headers: map[string]string{}}
+		schedule(request)
+
+		response := &requestcontrol.Response{Headers: map[string]string{}}
+		trace.PostResponse(ctx, request, response, podA.GetPod())
+		assert.NotContains(t, response.Headers, scorer.ScoringTraceResponseHeader)
+	})
+
+	t.Run("traced scorer returns the scores unchanged", func(t *testing.T) {
+		request := &types.LLMRequest{RequestId: "unchanged", Headers: map[string]string{scorer.ScoringTraceRequestHeader: "true"}}
+		assert.Equal(t, counting.Score(ctx, nil, request, pods), tracedScorers[0].Score(ctx, nil, request, pods))
+	})
+}
@@ -2,8 +2,15 @@ package scorer
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
@@ -12,6 +19,8 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
 )
 
 const (
@@ -19,30 +28,106 @@ const (
 	SessionAffinityType = "session-affinity-scorer"
 
 	sessionTokenHeader = "x-session-token" // name of the session header in request
+
+	sessionTokenSignatureSeparator = "." // separates the pod name from its signature in signed session tokens
+
+	cookieHeader    = "cookie"     // name of the cookie header in request
+	setCookieHeader = "set-cookie" // name of the set cookie header in response
+
+	// SessionTransportHeader carries the session token in the x-session-token header
+	SessionTransportHeader = "header"
+	// SessionTransportCookie carries the session token in a cookie
+	SessionTransportCookie = "cookie"
+
+	// SessionCookieNameDefault is the default name of the session cookie
+	SessionCookieNameDefault = "llm-d-session"
+
+	defaultSessionIDHeader   = "x-session-id"
+	defaultRebalanceFraction = 0.25
+	defaultStickyScore       = 1.0
+	defaultMaxSessions       = 10000
+	sessionIdleTimeout       = 10 * time.Minute
 )
 
+type sessionAffinityParameters struct {
+	// SigningKey is the key used to sign session tokens, when empty session tokens are not signed
+	SigningKey string `json:"signingKey"`
+	// Transport defines how the session token is carried, either "header" (default) or "cookie"
+	Transport string `json:"transport"`
+	// CookieName is the name of the session cookie when using the cookie transport
+	CookieName string `json:"cookieName"`
+	// HotPodQueueThreshold is the waiting queue size at which a pod is considered hot, causing the
+	// affinity of its least recently active sessions to be broken. 0 disables rebalancing.
+	HotPodQueueThreshold int `json:"hotPodQueueThreshold"`
+	// RebalanceFraction is the fraction (0-1] of the sessions of a hot pod, least recently active
+	// first, whose affinity is broken
+	RebalanceFraction float64 `json:"rebalanceFraction"`
+	// SessionIDHeader is the name of the request header identifying the session
+	SessionIDHeader string `json:"sessionIdHeader"`
+	// MaxSessions is the maximal number of sessions tracked for rebalancing, the least recently active
+	// sessions are dropped first
+	MaxSessions int `json:"maxSessions"`
+	// StickyScore is the score, in range of (0-1], given to the sticky pod of a session
+	StickyScore float64 `json:"stickyScore"`
+	// BreakAffinityAboveQueue is the waiting queue size of the sticky pod above which its sticky
+	// score is 0, moving the session elsewhere. 0 disables breaking the affinity.
+	BreakAffinityAboveQueue int `json:"breakAffinityAboveQueue"`
+}
+
 // compile-time type assertion
 var _ framework.Scorer = &SessionAffinity{}
 var _ requestcontrol.PostResponse = &SessionAffinity{}
 
 // SessionAffinityFactory defines the factory function for SessionAffinity scorer.
-func SessionAffinityFactory(name string, _ json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
-	return NewSessionAffinity().WithName(name), nil
+func SessionAffinityFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := sessionAffinityParameters{StickyScore: defaultStickyScore}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", SessionAffinityType, err)
+		}
+	}
+
+	scorer, err := NewSessionAffinity().WithSigningKey(parameters.SigningKey).WithTransport(parameters.Transport, parameters.CookieName)
+	if err == nil {
+		scorer, err = scorer.WithStickiness(parameters.StickyScore, parameters.BreakAffinityAboveQueue)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", SessionAffinityType, err)
+	}
+
+	return scorer.WithRebalancing(parameters.HotPodQueueThreshold, parameters.RebalanceFraction,
+		parameters.SessionIDHeader, parameters.MaxSessions).WithName(name), nil
 }
 
 // NewSessionAffinity returns a scorer
 func NewSessionAffinity() *SessionAffinity {
 	return &SessionAffinity{
-		typedName: plugins.TypedName{Type: SessionAffinityType},
+		typedName:   plugins.TypedName{Type: SessionAffinityType},
+		stickyScore: defaultStickyScore,
 	}
 }
 
 // SessionAffinity is a routing scorer that routes subsequent
 // requests in a session to the same pod as the first request in the
-// session was sent to, by giving that pod the specified weight and assigning
+// session was sent to, by giving that pod the sticky score and assigning
 // zero score to the rest of the targets
 type SessionAffinity struct {
 	typedName plugins.TypedName
+	// signingKey is the HMAC key used to sign and verify session tokens, nil if tokens are not signed
+	signingKey []byte
+	// cookieName is the name of the session cookie, empty if the session token is carried in a header
+	cookieName string
+
+	// hotPodQueueThreshold is the waiting queue size at which a pod is considered hot, 0 if rebalancing is disabled
+	hotPodQueueThreshold int
+	rebalanceFraction    float64
+	sessionIDHeader      string
+	sessions             *sessionTracker
+
+	// stickyScore is the score given to the sticky pod of a session
+	stickyScore float64
+	// breakAffinityAboveQueue is the waiting queue size of the sticky pod above which its score is 0, 0 if disabled
+	breakAffinityAboveQueue int
 }
 
 // TypedName returns the typed name of the plugin.
@@ -56,35 +141,145 @@ func (s *SessionAffinity) WithName(name string) *SessionAffinity {
 	return s
 }
 
+// WithSigningKey sets the key used to sign session tokens. When set, session tokens carry an
+// HMAC-SHA256 signature of the pod name, and tokens with a missing or invalid signature are ignored.
+// An empty key disables signing.
+func (s *SessionAffinity) WithSigningKey(key string) *SessionAffinity {
+	s.signingKey = nil
+	if key != "" {
+		s.signingKey = []byte(key)
+	}
+	return s
+}
+
+// WithTransport sets how the session token is carried, either SessionTransportHeader or SessionTransportCookie.
+// An empty transport means SessionTransportHeader. The cookie name is only used by the cookie transport,
+// and defaults to SessionCookieNameDefault.
+func (s *SessionAffinity) WithTransport(transport string, cookieName string) (*SessionAffinity, error) {
+	switch transport {
+	case "", SessionTransportHeader:
+		s.cookieName = ""
+	case SessionTransportCookie:
+		if cookieName == "" {
+			cookieName = SessionCookieNameDefault
+		}
+		if err := (&http.Cookie{Name: cookieName}).Valid(); err != nil {
+			return nil, fmt.Errorf("invalid cookie name '%s' - %w", cookieName, err)
+		}
+		s.cookieName = cookieName
+	default:
+		return nil, fmt.Errorf("unknown transport '%s', expected one of '%s' or '%s'",
+			transport, SessionTransportHeader, SessionTransportCookie)
+	}
+	return s, nil
+}
+
+// WithRebalancing enables rebalancing sessions off hot pods. Once the waiting queue of a pod reaches
+// hotPodQueueThreshold, the affinity of the given fraction of its sessions, least recently active first,
+// is broken so they are scheduled elsewhere. Sessions are identified by the sessionIDHeader request header,
+// and up to maxSessions of them are tracked, the least recently active are dropped first.
+// A non positive hotPodQueueThreshold disables rebalancing.
+func (s *SessionAffinity) WithRebalancing(hotPodQueueThreshold int, rebalanceFraction float64, sessionIDHeader string,
+	maxSessions int) *SessionAffinity {
+	if hotPodQueueThreshold <= 0 {
+		s.hotPodQueueThreshold = 0
+		s.sessions = nil
+		return s
+	}
+	if rebalanceFraction <= 0 || rebalanceFraction > 1 {
+		rebalanceFraction = defaultRebalanceFraction
+	}
+	if sessionIDHeader == "" {
+		sessionIDHeader = defaultSessionIDHeader
+	}
+	if maxSessions <= 0 {
+		maxSessions = defaultMaxSessions
+	}
+
+	s.hotPodQueueThreshold = hotPodQueueThreshold
+	s.rebalanceFraction = rebalanceFraction
+	s.sessionIDHeader = sessionIDHeader
+	s.sessions = newSessionTracker(sessionIdleTimeout, maxSessions)
+	return s
+}
+
+// WithStickiness sets the score, in range of (0-1], given to the sticky pod of a session. A score
+// below 1 lets a sufficiently loaded sticky pod lose to other pods, when combined with load scorers.
+// Once the waiting queue of the sticky pod exceeds breakAffinityAboveQueue, it is scored with 0,
+// moving the session elsewhere. A non positive breakAffinityAboveQueue never breaks the affinity.
+func (s *SessionAffinity) WithStickiness(stickyScore float64, breakAffinityAboveQueue int) (*SessionAffinity, error) {
+	if stickyScore <= 0 || stickyScore > 1 {
+		return nil, errors.New("stickyScore must be in range of 0-1, excluding 0")
+	}
+	s.stickyScore = stickyScore
+	s.breakAffinityAboveQueue = max(breakAffinityAboveQueue, 0)
+	return s, nil
+}
+
 // Score assign a high score to the pod used in previous requests and zero to others
 func (s *SessionAffinity) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
 	scoredPods := make(map[types.Pod]float64)
-	sessionToken := request.Headers[sessionTokenHeader]
+	sessionToken := s.requestToken(request)
 	podName := ""
 
 	if sessionToken != "" {
-		decodedBytes, err := base64.StdEncoding.DecodeString(sessionToken)
-		if err != nil {
-			log.FromContext(ctx).Error(err, "Error decoding session header")
+		if s.signingKey != nil {
+			name, err := s.verifyToken(sessionToken)
+			if err != nil {
+				log.FromContext(ctx).V(logutil.DEBUG).Info("Ignoring session header", "reason", err.Error())
+			} else {
+				podName = name
+			}
 		} else {
-			podName = string(decodedBytes)
+			decodedBytes, err := base64.StdEncoding.DecodeString(sessionToken)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Error decoding session header")
+			} else {
+				podName = string(decodedBytes)
+			}
 		}
 	}
 	for _, pod := range pods {
 		scoredPods[pod] = 0.0 // initial value
-		if pod.GetPod().NamespacedName.String() == podName {
-			scoredPods[pod] = 1.0
+		if pod.GetPod().NamespacedName.String() == podName && !s.overloaded(ctx, pod) && !s.rebalance(ctx, request, pod) {
+			scoredPods[pod] = s.stickyScore
 		}
 	}
 
 	return scoredPods
 }
 
-// PostResponse sets the session header on the response sent to the client
-// TODO: this should be using a cookie and ensure not overriding any other
-// cookie values if present.
-// Tracked in https://github.com/llm-d/llm-d-inference-scheduler/issues/28
-func (s *SessionAffinity) PostResponse(ctx context.Context, _ *types.LLMRequest, response *requestcontrol.Response, targetPod *backend.Pod) {
+// overloaded returns true if the waiting queue of the given sticky pod exceeds the ceiling above
+// which the affinity is broken
+func (s *SessionAffinity) overloaded(ctx context.Context, pod types.Pod) bool {
+	if s.breakAffinityAboveQueue <= 0 || pod.GetMetrics().WaitingQueueSize <= s.breakAffinityAboveQueue {
+		return false
+	}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Breaking session affinity to overloaded pod", "pod", pod.GetPod().NamespacedName.String(),
+		"waitingQueueSize", pod.GetMetrics().WaitingQueueSize)
+	return true
+}
+
+// rebalance returns true if the affinity of the request's session to the given hot pod should be broken
+func (s *SessionAffinity) rebalance(ctx context.Context, request *types.LLMRequest, pod types.Pod) bool {
+	if s.sessions == nil || pod.GetMetrics().WaitingQueueSize < s.hotPodQueueThreshold {
+		return false
+	}
+	sessionID := request.Headers[s.sessionIDHeader]
+	if sessionID == "" {
+		return false
+	}
+
+	podName := pod.GetPod().NamespacedName.String()
+	if !s.sessions.rebalance(podName, sessionID, s.rebalanceFraction, time.Now()) {
+		return false
+	}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Breaking session affinity to hot pod", "pod", podName, "session", sessionID)
+	return true
+}
+
+// PostResponse sets the session header, or the session cookie, on the response sent to the client
+func (s *SessionAffinity) PostResponse(ctx context.Context, request *types.LLMRequest, response *requestcontrol.Response, targetPod *backend.Pod) {
 	if response == nil || targetPod == nil {
 		reqID := "undefined"
 		if response != nil {
@@ -98,5 +293,85 @@ func (s *SessionAffinity) PostResponse(ctx context.Context, _ *types.LLMRequest,
 		response.Headers = make(map[string]string)
 	}
 
-	response.Headers[sessionTokenHeader] = base64.StdEncoding.EncodeToString([]byte(targetPod.NamespacedName.String()))
+	if s.sessions != nil && request != nil {
+		if sessionID := request.Headers[s.sessionIDHeader]; sessionID != "" {
+			s.sessions.touch(targetPod.NamespacedName.String(), sessionID, time.Now())
+		}
+	}
+
+	token := s.token(targetPod.NamespacedName.String())
+	if s.cookieName == "" {
+		response.Headers[sessionTokenHeader] = token
+		return
+	}
+
+	// Set-Cookie headers must not be folded into a single value, and the proxy replaces the response
+	// headers with the returned ones, so a session cookie would replace the cookie set by the model
+	// server. The cookie of the model server is kept instead, and the session cookie is set on a
+	// later response of the session.
+	if response.Headers[setCookieHeader] != "" {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Session affinity scorer - skip setting the session cookie, the response already sets a cookie",
+			"req id", response.RequestId)
+		return
+	}
+	response.Headers[setCookieHeader] = (&http.Cookie{Name: s.cookieName, Value: token, Path: "/", HttpOnly: true,
+		SameSite: http.SameSiteLaxMode}).String()
+}
+
+// requestToken returns the session token of the given request, or an empty string if it has none
+func (s *SessionAffinity) requestToken(request *types.LLMRequest) string {
+	if request == nil {
+		return ""
+	}
+	if s.cookieName == "" {
+		return request.Headers[sessionTokenHeader]
+	}
+
+	cookies := request.Headers[cookieHeader]
+	if cookies == "" {
+		return ""
+	}
+	// parsing through a request skips malformed cookies instead of failing on them
+	cookie, err := (&http.Request{Header: http.Header{"Cookie": []string{cookies}}}).Cookie(s.cookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// token returns the session token of the given pod, signed if a signing key is set
+func (s *SessionAffinity) token(podName string) string {
+	token := base64.StdEncoding.EncodeToString([]byte(podName))
+	if s.signingKey != nil {
+		token += sessionTokenSignatureSeparator + base64.StdEncoding.EncodeToString(s.sign(podName))
+	}
+	return token
+}
+
+// verifyToken returns the pod name of the given signed session token if its signature is valid
+func (s *SessionAffinity) verifyToken(token string) (string, error) {
+	encodedName, encodedSignature, found := strings.Cut(token, sessionTokenSignatureSeparator)
+	if !found {
+		return "", errors.New("session token is not signed")
+	}
+
+	name, err := base64.StdEncoding.DecodeString(encodedName)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode session token - %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode session token signature - %w", err)
+	}
+	if !hmac.Equal(signature, s.sign(string(name))) {
+		return "", errors.New("invalid session token signature")
+	}
+	return string(name), nil
+}
+
+// sign returns the HMAC-SHA256 signature of the given pod name
+func (s *SessionAffinity) sign(podName string) []byte {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(podName))
+	return mac.Sum(nil)
 }
@@ -3,6 +3,8 @@ package scorer_test
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -143,3 +145,313 @@ func TestSessionAffinity_PostResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionAffinity_SignedTokens(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	inputPods := []types.Pod{podA, podB}
+
+	s := scorer.NewSessionAffinity().WithSigningKey("secret")
+	ctx := context.Background()
+
+	// obtain a signed token for podB
+	response := &requestcontrol.Response{RequestId: "req-1"}
+	s.PostResponse(ctx, nil, response, podB.GetPod())
+	validToken := response.Headers["x-session-token"]
+
+	encodedName, encodedSignature, found := strings.Cut(validToken, ".")
+	if !found {
+		t.Fatalf("Expected a signed session token, got %q", validToken)
+	}
+	if encodedName != base64.StdEncoding.EncodeToString([]byte(podB.GetPod().NamespacedName.String())) {
+		t.Errorf("Unexpected pod name in session token %q", validToken)
+	}
+
+	forgedName := base64.StdEncoding.EncodeToString([]byte(podA.GetPod().NamespacedName.String()))
+	otherKeyToken := func() string {
+		other := scorer.NewSessionAffinity().WithSigningKey("other-secret")
+		response := &requestcontrol.Response{RequestId: "req-2"}
+		other.PostResponse(ctx, nil, response, podA.GetPod())
+		return response.Headers["x-session-token"]
+	}()
+
+	tests := []struct {
+		name       string
+		token      string
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:       "valid signed token",
+			token:      validToken,
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 1.0},
+		},
+		{
+			name:       "tampered pod name",
+			token:      forgedName + "." + encodedSignature,
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+		{
+			name:       "signed with another key",
+			token:      otherKeyToken,
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+		{
+			name:       "missing signature",
+			token:      encodedName,
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+		{
+			name:       "garbage signature",
+			token:      encodedName + ".garbage",
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &types.LLMRequest{Headers: map[string]string{"x-session-token": test.token}}
+			gotScores := s.Score(ctx, nil, req, inputPods)
+
+			if diff := cmp.Diff(test.wantScores, gotScores); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSessionAffinity_CookieTransport(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	inputPods := []types.Pod{podA, podB}
+
+	s, err := scorer.NewSessionAffinity().WithTransport(scorer.SessionTransportCookie, "session")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	tokenForPodB := base64.StdEncoding.EncodeToString([]byte(podB.GetPod().NamespacedName.String()))
+
+	t.Run("set cookie formatting", func(t *testing.T) {
+		response := &requestcontrol.Response{RequestId: "req-1"}
+		s.PostResponse(ctx, nil, response, podB.GetPod())
+
+		wantHeaders := map[string]string{"set-cookie": "session=" + tokenForPodB + "; Path=/; HttpOnly; SameSite=Lax"}
+		if diff := cmp.Diff(wantHeaders, response.Headers); diff != "" {
+			t.Errorf("Unexpected output (-want +got): %v", diff)
+		}
+	})
+
+	t.Run("upstream set cookie is kept", func(t *testing.T) {
+		response := &requestcontrol.Response{
+			RequestId: "req-2",
+			Headers:   map[string]string{"set-cookie": "theme=dark; Path=/", "content-type": "application/json"},
+		}
+		s.PostResponse(ctx, nil, response, podB.GetPod())
+
+		// the returned headers replace the response headers, so the session cookie is not set over the upstream cookie
+		wantHeaders := map[string]string{
+			"set-cookie":   "theme=dark; Path=/",
+			"content-type": "application/json",
+		}
+		if diff := cmp.Diff(wantHeaders, response.Headers); diff != "" {
+			t.Errorf("Unexpected output (-want +got): %v", diff)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantScores map[types.Pod]float64
+	}{
+		{
+			name:       "session cookie only",
+			headers:    map[string]string{"cookie": "session=" + tokenForPodB},
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 1.0},
+		},
+		{
+			name:       "session cookie among multiple cookies",
+			headers:    map[string]string{"cookie": "theme=dark; session=" + tokenForPodB + "; lang=en"},
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 1.0},
+		},
+		{
+			name:       "session cookie after a malformed cookie",
+			headers:    map[string]string{"cookie": "bad cookie; session=" + tokenForPodB},
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 1.0},
+		},
+		{
+			name:       "other cookies only",
+			headers:    map[string]string{"cookie": "theme=dark; lang=en"},
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+		{
+			name:       "session header is ignored",
+			headers:    map[string]string{"x-session-token": tokenForPodB},
+			wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotScores := s.Score(ctx, nil, &types.LLMRequest{Headers: test.headers}, inputPods)
+
+			if diff := cmp.Diff(test.wantScores, gotScores); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestSessionAffinity_InvalidTransport(t *testing.T) {
+	if _, err := scorer.NewSessionAffinity().WithTransport("query", ""); err == nil {
+		t.Error("Expected error for an unknown transport")
+	}
+	if _, err := scorer.NewSessionAffinity().WithTransport(scorer.SessionTransportCookie, "bad name"); err == nil {
+		t.Error("Expected error for an invalid cookie name")
+	}
+}
+
+func TestSessionAffinity_Rebalancing(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: 0},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: 0},
+	}
+	inputPods := []types.Pod{podA, podB}
+
+	s := scorer.NewSessionAffinity().WithRebalancing(5, 0.5, "x-session-id", 0)
+	ctx := context.Background()
+
+	request := func(session string) *types.LLMRequest {
+		return &types.LLMRequest{Headers: map[string]string{"x-session-id": session}}
+	}
+
+	// pin four sessions to podA, session-1 being the least recently active
+	var token string
+	sessions := []string{"session-1", "session-2", "session-3", "session-4"}
+	for _, session := range sessions {
+		response := &requestcontrol.Response{}
+		s.PostResponse(ctx, request(session), response, podA.GetPod())
+		token = response.Headers["x-session-token"]
+	}
+
+	pinned := map[types.Pod]float64{podA: 1.0, podB: 0.0}
+	unpinned := map[types.Pod]float64{podA: 0.0, podB: 0.0}
+	scoreSession := func(session string) map[types.Pod]float64 {
+		req := request(session)
+		req.Headers["x-session-token"] = token
+		return s.Score(ctx, nil, req, inputPods)
+	}
+
+	t.Run("sessions keep affinity while the pod is not hot", func(t *testing.T) {
+		for _, session := range sessions {
+			if diff := cmp.Diff(pinned, scoreSession(session)); diff != "" {
+				t.Errorf("Unexpected output for %s (-want +got): %v", session, diff)
+			}
+		}
+	})
+
+	podA.WaitingQueueSize = 5
+
+	t.Run("hot pod sheds its least active sessions", func(t *testing.T) {
+		want := map[string]map[types.Pod]float64{
+			"session-1": unpinned,
+			"session-2": unpinned,
+			"session-3": pinned,
+			"session-4": pinned,
+		}
+		for _, session := range sessions {
+			if diff := cmp.Diff(want[session], scoreSession(session)); diff != "" {
+				t.Errorf("Unexpected output for %s (-want +got): %v", session, diff)
+			}
+		}
+	})
+
+	t.Run("requests without a session id keep affinity", func(t *testing.T) {
+		if diff := cmp.Diff(pinned, scoreSession("")); diff != "" {
+			t.Errorf("Unexpected output (-want +got): %v", diff)
+		}
+	})
+
+	t.Run("rebalanced sessions move to another pod", func(t *testing.T) {
+		s.PostResponse(ctx, request("session-1"), &requestcontrol.Response{}, podB.GetPod())
+		s.PostResponse(ctx, request("session-2"), &requestcontrol.Response{}, podB.GetPod())
+		s.PostResponse(ctx, request("session-3"), &requestcontrol.Response{}, podA.GetPod())
+
+		// podA now holds session-4 and the more recently active session-3
+		if diff := cmp.Diff(unpinned, scoreSession("session-4")); diff != "" {
+			t.Errorf("Unexpected output for session-4 (-want +got): %v", diff)
+		}
+		if diff := cmp.Diff(pinned, scoreSession("session-3")); diff != "" {
+			t.Errorf("Unexpected output for session-3 (-want +got): %v", diff)
+		}
+	})
+}
+
+func TestSessionAffinity_Stickiness(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	inputPods := []types.Pod{podA, podB}
+	request := &types.LLMRequest{
+		Headers: map[string]string{"x-session-token": base64.StdEncoding.EncodeToString([]byte(podA.GetPod().NamespacedName.String()))},
+	}
+
+	s, err := scorer.NewSessionAffinity().WithStickiness(0.6, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		waitingQueueSize int
+		wantScores       map[types.Pod]float64
+	}{
+		{name: "sticky pod gets the sticky score", waitingQueueSize: 0, wantScores: map[types.Pod]float64{podA: 0.6, podB: 0.0}},
+		{name: "sticky pod at the ceiling keeps the affinity", waitingQueueSize: 10, wantScores: map[types.Pod]float64{podA: 0.6, podB: 0.0}},
+		{name: "sticky pod above the ceiling breaks the affinity", waitingQueueSize: 11, wantScores: map[types.Pod]float64{podA: 0.0, podB: 0.0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			podA.WaitingQueueSize = test.waitingQueueSize
+			if diff := cmp.Diff(test.wantScores, s.Score(context.Background(), nil, request, inputPods)); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+
+	for _, stickyScore := range []float64{0, -0.5, 1.5} {
+		if _, err := scorer.NewSessionAffinity().WithStickiness(stickyScore, 0); err == nil {
+			t.Errorf("Expected error for sticky score %v", stickyScore)
+		}
+	}
+}
+
+func TestSessionAffinityFactory_Stickiness(t *testing.T) {
+	if _, err := scorer.SessionAffinityFactory("session", json.RawMessage(`{"stickyScore": 0.5, "breakAffinityAboveQueue": 20}`), nil); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := scorer.SessionAffinityFactory("session", json.RawMessage(`{"stickyScore": 2}`), nil); err == nil {
+		t.Error("Expected error for a sticky score above 1")
+	}
+}
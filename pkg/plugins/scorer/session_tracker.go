@@ -0,0 +1,127 @@
+package scorer
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// trackedSession holds the pod a session is pinned to and the time of its last activity.
+type trackedSession struct {
+	id         string
+	podName    string
+	lastActive time.Time
+	// element is the element of the session in the list of all sessions
+	element *list.Element
+	// podElement is the element of the session in the list of the sessions of its pod
+	podElement *list.Element
+}
+
+// sessionTracker tracks the last activity time of the sessions pinned to each pod, so that the
+// least recently active sessions of a hot pod can be rebalanced to other pods.
+// Sessions are kept in lists ordered by their last activity, least recently active first, so idle
+// sessions are pruned across all pods, including pods that left the pool, without scanning the
+// active sessions.
+type sessionTracker struct {
+	// idleTimeout defines how long a session is tracked after its last activity
+	idleTimeout time.Duration
+	// maxSessions defines the maximal number of tracked sessions, the least recently active are dropped first
+	maxSessions int
+
+	// sessions maps each session ID to its tracked session
+	sessions map[string]*trackedSession
+	// all holds all the tracked sessions, least recently active first
+	all *list.List
+	// pods maps each pod to its tracked sessions, least recently active first
+	pods  map[string]*list.List
+	mutex sync.Mutex
+}
+
+func newSessionTracker(idleTimeout time.Duration, maxSessions int) *sessionTracker {
+	return &sessionTracker{
+		idleTimeout: idleTimeout,
+		maxSessions: maxSessions,
+		sessions:    make(map[string]*trackedSession),
+		all:         list.New(),
+		pods:        make(map[string]*list.List),
+	}
+}
+
+// touch records the activity of the given session on the given pod
+func (t *sessionTracker) touch(podName string, sessionID string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if session, found := t.sessions[sessionID]; found {
+		t.removeLocked(session)
+	}
+
+	session := &trackedSession{id: sessionID, podName: podName, lastActive: now}
+	podSessions, found := t.pods[podName]
+	if !found {
+		podSessions = list.New()
+		t.pods[podName] = podSessions
+	}
+	session.element = t.all.PushBack(session)
+	session.podElement = podSessions.PushBack(session)
+	t.sessions[sessionID] = session
+
+	t.pruneLocked(now)
+}
+
+// rebalance returns true if the given session is among the given fraction of the least recently
+// active sessions of the given pod. A rebalanced session stays tracked on the pod until its next
+// activity is recorded on another pod.
+func (t *sessionTracker) rebalance(podName string, sessionID string, fraction float64, now time.Time) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pruneLocked(now)
+	session, found := t.sessions[sessionID]
+	if !found || session.podName != podName {
+		return false
+	}
+
+	// only the least recently active sessions within the fraction are visited
+	podSessions := t.pods[podName]
+	limit := int(math.Ceil(float64(podSessions.Len()) * fraction))
+	for element, rank := podSessions.Front(), 0; element != nil && rank < limit; element, rank = element.Next(), rank+1 {
+		if element == session.podElement {
+			return true
+		}
+	}
+	return false
+}
+
+// size returns the number of tracked sessions
+func (t *sessionTracker) size() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.sessions)
+}
+
+// pruneLocked stops tracking the idle sessions of all pods, and the least recently active sessions
+// beyond the maximal number of sessions. Must be called with the mutex held.
+func (t *sessionTracker) pruneLocked(now time.Time) {
+	for element := t.all.Front(); element != nil; element = t.all.Front() {
+		session := element.Value.(*trackedSession)
+		if now.Sub(session.lastActive) <= t.idleTimeout && len(t.sessions) <= t.maxSessions {
+			return
+		}
+		t.removeLocked(session)
+	}
+}
+
+// removeLocked stops tracking the given session. Must be called with the mutex held.
+func (t *sessionTracker) removeLocked(session *trackedSession) {
+	delete(t.sessions, session.id)
+	t.all.Remove(session.element)
+	if podSessions, found := t.pods[session.podName]; found {
+		podSessions.Remove(session.podElement)
+		if podSessions.Len() == 0 {
+			delete(t.pods, session.podName)
+		}
+	}
+}
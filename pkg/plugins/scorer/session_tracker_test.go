@@ -0,0 +1,60 @@
+package scorer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionTracker_Rebalance(t *testing.T) {
+	tracker := newSessionTracker(time.Minute, 100)
+	now := time.Now()
+
+	for i, session := range []string{"session-1", "session-2", "session-3", "session-4"} {
+		tracker.touch("pod-a", session, now.Add(time.Duration(i)*time.Second))
+	}
+	tracker.touch("pod-b", "session-5", now)
+
+	assert.True(t, tracker.rebalance("pod-a", "session-1", 0.5, now))
+	assert.True(t, tracker.rebalance("pod-a", "session-2", 0.5, now))
+	assert.False(t, tracker.rebalance("pod-a", "session-3", 0.5, now))
+	assert.False(t, tracker.rebalance("pod-a", "session-4", 0.5, now))
+	// sessions of other pods are not rebalanced off the pod
+	assert.False(t, tracker.rebalance("pod-a", "session-5", 0.5, now))
+
+	// a touched session becomes the most recently active
+	tracker.touch("pod-a", "session-1", now.Add(10*time.Second))
+	assert.False(t, tracker.rebalance("pod-a", "session-1", 0.5, now.Add(10*time.Second)))
+	assert.True(t, tracker.rebalance("pod-a", "session-3", 0.5, now.Add(10*time.Second)))
+}
+
+func TestSessionTracker_PrunesIdleSessionsOfAllPods(t *testing.T) {
+	tracker := newSessionTracker(time.Minute, 100)
+	now := time.Now()
+
+	// sessions pinned to a pod that left the pool are never touched again
+	tracker.touch("removed-pod", "session-1", now)
+	tracker.touch("removed-pod", "session-2", now)
+	assert.Equal(t, 2, tracker.size())
+
+	tracker.touch("pod-a", "session-3", now.Add(2*time.Minute))
+	assert.Equal(t, 1, tracker.size())
+	assert.NotContains(t, tracker.pods, "removed-pod")
+}
+
+func TestSessionTracker_MaxSessions(t *testing.T) {
+	tracker := newSessionTracker(time.Minute, 3)
+	now := time.Now()
+
+	for i, session := range []string{"session-1", "session-2", "session-3", "session-4", "session-5"} {
+		tracker.touch("pod-a", session, now.Add(time.Duration(i)*time.Second))
+	}
+
+	// the least recently active sessions are dropped first
+	assert.Equal(t, 3, tracker.size())
+	assert.NotContains(t, tracker.sessions, "session-1")
+	assert.NotContains(t, tracker.sessions, "session-2")
+	assert.Contains(t, tracker.sessions, "session-5")
+	assert.Equal(t, 3, tracker.pods["pod-a"].Len())
+}
@@ -0,0 +1,142 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// SLOComplianceType is the type of the SLOCompliance scorer.
+	SLOComplianceType = "slo-compliance-scorer"
+
+	// defaultLatencyTarget defines the default latency SLO target.
+	defaultLatencyTarget = 2 * time.Second
+)
+
+// SLOComplianceParameters defines the parameters for the SLOCompliance scorer.
+type SLOComplianceParameters struct {
+	// LatencyTarget defines the latency SLO target, measured from sending the request to the pod
+	// until receiving its response headers.
+	// This field accepts duration strings like "500ms", "2s".
+	LatencyTarget string `json:"latencyTarget"`
+	// Window defines the sliding window over which SLO compliance is measured.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	Window string `json:"window"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer            = &SLOCompliance{}
+	_ requestcontrol.PreRequest   = &SLOCompliance{}
+	_ requestcontrol.PostResponse = &SLOCompliance{}
+)
+
+// SLOComplianceFactory defines the factory function for the SLOCompliance scorer.
+func SLOComplianceFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := SLOComplianceParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", SLOComplianceType, err)
+		}
+	}
+
+	return NewSLOCompliance(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewSLOCompliance creates a new SLOCompliance scorer.
+func NewSLOCompliance(ctx context.Context, params *SLOComplianceParameters) *SLOCompliance {
+	logger := log.FromContext(ctx)
+	latencyTarget := defaultLatencyTarget
+	window := defaultLatencyWindow
+
+	if params != nil && params.LatencyTarget != "" {
+		paramsLatencyTarget, err := time.ParseDuration(params.LatencyTarget)
+		if err != nil || paramsLatencyTarget <= 0 {
+			logger.Error(err, "Invalid latency target duration, using default latency target")
+		} else {
+			latencyTarget = paramsLatencyTarget
+		}
+	}
+	if params != nil && params.Window != "" {
+		paramsWindow, err := time.ParseDuration(params.Window)
+		if err != nil || paramsWindow <= 0 {
+			logger.Error(err, "Invalid SLO window duration, using default window")
+		} else {
+			window = paramsWindow
+		}
+	}
+
+	return &SLOCompliance{
+		typedName:     plugins.TypedName{Type: SLOComplianceType},
+		latencyTarget: latencyTarget,
+		tracker:       newLatencyTracker(window, defaultRequestTimeout),
+	}
+}
+
+// SLOCompliance is a scorer that prefers pods meeting their latency SLO. The latency of each
+// request is measured from sending it to the pod until receiving its response headers, and
+// each pod is scored by the rate of its recent requests within the latency target.
+type SLOCompliance struct {
+	typedName     plugins.TypedName
+	latencyTarget time.Duration
+	tracker       *latencyTracker
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *SLOCompliance) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *SLOCompliance) WithName(name string) *SLOCompliance {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by their recent SLO compliance rate, in range of 0-1.
+// Pods without recent requests are scored with 1.
+func (s *SLOCompliance) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		latencies := s.tracker.latencies(pod.GetPod().NamespacedName.String())
+		if len(latencies) == 0 {
+			scoredPods[pod] = 1.0
+			continue
+		}
+
+		compliant := 0
+		for _, latency := range latencies {
+			if latency <= s.latencyTarget {
+				compliant++
+			}
+		}
+		scoredPods[pod] = float64(compliant) / float64(len(latencies))
+	}
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods", "scores", scoredPods)
+	return scoredPods
+}
+
+// PreRequest records the time the request is sent to its target pod.
+func (s *SLOCompliance) PreRequest(_ context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	s.tracker.requestSent(request, schedulingResult)
+}
+
+// PostResponse records the latency of the request on the pod that served it.
+func (s *SLOCompliance) PostResponse(ctx context.Context, request *types.LLMRequest, _ *requestcontrol.Response, targetPod *backend.Pod) {
+	if latency, found := s.tracker.responseReceived(request, targetPod); found {
+		log.FromContext(ctx).V(logutil.TRACE).Info("Recorded request latency", "pod", targetPod.NamespacedName, "latency", latency)
+	}
+}
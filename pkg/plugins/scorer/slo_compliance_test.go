@@ -0,0 +1,96 @@
+package scorer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+// primaryResult returns a scheduling result targeting the given pod in its primary profile.
+func primaryResult(pod types.Pod) *types.SchedulingResult {
+	return &types.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults: map[string]*types.ProfileRunResult{
+			"default": {TargetPods: []types.Pod{pod}},
+		},
+	}
+}
+
+func TestSLOCompliance_Score(t *testing.T) {
+	ctx := context.Background()
+
+	compliantPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "compliant", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	violatingPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "violating", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	newPod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "new", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	s := scorer.NewSLOCompliance(ctx, &scorer.SLOComplianceParameters{LatencyTarget: "50ms"})
+
+	// two slow requests on the violating pod
+	slowRequests := []*types.LLMRequest{{RequestId: "slow-1"}, {RequestId: "slow-2"}}
+	for _, request := range slowRequests {
+		s.PreRequest(ctx, request, primaryResult(violatingPod), 0)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// fast requests on both pods
+	for i := range 4 {
+		request := &types.LLMRequest{RequestId: fmt.Sprintf("fast-compliant-%d", i)}
+		s.PreRequest(ctx, request, primaryResult(compliantPod), 0)
+		s.PostResponse(ctx, request, &requestcontrol.Response{}, compliantPod.GetPod())
+	}
+	for i := range 2 {
+		request := &types.LLMRequest{RequestId: fmt.Sprintf("fast-violating-%d", i)}
+		s.PreRequest(ctx, request, primaryResult(violatingPod), 0)
+		s.PostResponse(ctx, request, &requestcontrol.Response{}, violatingPod.GetPod())
+	}
+	for _, request := range slowRequests {
+		s.PostResponse(ctx, request, &requestcontrol.Response{}, violatingPod.GetPod())
+	}
+
+	got := s.Score(ctx, nil, nil, []types.Pod{compliantPod, violatingPod, newPod})
+	want := map[types.Pod]float64{
+		compliantPod: 1.0,
+		violatingPod: 0.5,
+		newPod:       1.0,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected output (-want +got): %v", diff)
+	}
+}
+
+func TestSLOCompliance_UnknownRequest(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	s := scorer.NewSLOCompliance(ctx, nil)
+	// response without a matching PreRequest is ignored
+	s.PostResponse(ctx, &types.LLMRequest{RequestId: "unknown"}, &requestcontrol.Response{}, pod.GetPod())
+	s.PostResponse(ctx, &types.LLMRequest{RequestId: "unknown"}, &requestcontrol.Response{}, nil)
+
+	if got := s.Score(ctx, nil, nil, []types.Pod{pod})[pod]; got != 1.0 {
+		t.Errorf("Expected score 1 for a pod without recorded latencies, got %v", got)
+	}
+}
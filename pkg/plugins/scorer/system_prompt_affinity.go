@@ -0,0 +1,197 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// SystemPromptAffinityType is the type of the SystemPromptAffinity scorer.
+	SystemPromptAffinityType = "system-prompt-affinity-scorer"
+
+	defaultSystemPromptLength  = 1024
+	defaultSystemPromptTimeout = 10 * time.Minute
+	defaultMaxSystemPrompts    = 10000
+)
+
+// SystemPromptAffinityParameters defines the parameters for the SystemPromptAffinity scorer.
+type SystemPromptAffinityParameters struct {
+	// Delimiter, when set, marks the end of the system prompt: the system prompt is the part of
+	// the prompt preceding the first occurrence of the delimiter. Prompts without the delimiter
+	// have no system prompt.
+	Delimiter string `json:"delimiter"`
+	// Length is the number of leading prompt characters considered as the system prompt when
+	// no delimiter is set. Prompts not longer than Length have no system prompt.
+	Length int `json:"length"`
+	// Timeout defines how long a pod is considered to hold a system prompt after its last request.
+	// This field accepts duration strings like "30s", "1m", "2h".
+	Timeout string `json:"timeout"`
+	// MaxEntries is the maximal number of tracked system prompt and pod pairs; the least recently
+	// used are dropped first.
+	MaxEntries int `json:"maxEntries"`
+}
+
+// compile-time type assertions
+var (
+	_ framework.Scorer          = &SystemPromptAffinity{}
+	_ requestcontrol.PreRequest = &SystemPromptAffinity{}
+)
+
+// SystemPromptAffinityFactory defines the factory function for the SystemPromptAffinity scorer.
+func SystemPromptAffinityFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := SystemPromptAffinityParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", SystemPromptAffinityType, err)
+		}
+	}
+
+	return NewSystemPromptAffinity(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewSystemPromptAffinity creates a new SystemPromptAffinity scorer.
+func NewSystemPromptAffinity(ctx context.Context, params *SystemPromptAffinityParameters) *SystemPromptAffinity {
+	delimiter := ""
+	length := defaultSystemPromptLength
+	timeout := defaultSystemPromptTimeout
+	maxEntries := defaultMaxSystemPrompts
+
+	if params != nil {
+		delimiter = params.Delimiter
+		if params.Length > 0 {
+			length = params.Length
+		}
+		if params.MaxEntries > 0 {
+			maxEntries = params.MaxEntries
+		}
+		if params.Timeout != "" {
+			paramsTimeout, err := time.ParseDuration(params.Timeout)
+			if err != nil || paramsTimeout <= 0 {
+				log.FromContext(ctx).Error(err, "Invalid system prompt timeout duration, using default timeout")
+			} else {
+				timeout = paramsTimeout
+			}
+		}
+	}
+
+	return &SystemPromptAffinity{
+		typedName: plugins.TypedName{Type: SystemPromptAffinityType},
+		delimiter: delimiter,
+		length:    length,
+		holders: ttlcache.New[systemPromptHolder, struct{}](
+			ttlcache.WithTTL[systemPromptHolder, struct{}](timeout),
+			ttlcache.WithCapacity[systemPromptHolder, struct{}](uint64(maxEntries)),
+			ttlcache.WithDisableTouchOnHit[systemPromptHolder, struct{}](),
+		),
+	}
+}
+
+// systemPromptHolder identifies a pod known to hold a system prompt
+type systemPromptHolder struct {
+	systemPrompt uint64
+	pod          string
+}
+
+// SystemPromptAffinity is a scorer that prefers pods which recently served requests sharing
+// the request's system prompt, and are therefore likely to hold it in their KV-cache. Unlike
+// prefix based scoring, only the system prompt portion of the prompt is considered, so requests
+// whose templated parts differ right after the system prompt still converge on the same pods.
+type SystemPromptAffinity struct {
+	typedName plugins.TypedName
+	delimiter string
+	length    int
+
+	// holders holds the recently used system prompt and pod pairs
+	holders *ttlcache.Cache[systemPromptHolder, struct{}]
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *SystemPromptAffinity) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *SystemPromptAffinity) WithName(name string) *SystemPromptAffinity {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the pods known to hold the request's system prompt with 1, all other pods are
+// scored with 0.
+func (s *SystemPromptAffinity) Score(_ context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 0.0
+	}
+
+	systemPrompt, found := s.systemPromptKey(request)
+	if !found {
+		return scoredPods
+	}
+
+	for _, pod := range pods {
+		if s.holders.Has(systemPromptHolder{systemPrompt: systemPrompt, pod: pod.GetPod().NamespacedName.String()}) {
+			scoredPods[pod] = 1.0
+		}
+	}
+	return scoredPods
+}
+
+// PreRequest records the pod chosen by the primary profile as holding the request's system prompt.
+func (s *SystemPromptAffinity) PreRequest(ctx context.Context, request *types.LLMRequest, schedulingResult *types.SchedulingResult, _ int) {
+	systemPrompt, found := s.systemPromptKey(request)
+	if !found {
+		return
+	}
+
+	profileResult := schedulingResult.ProfileResults[schedulingResult.PrimaryProfileName]
+	if profileResult == nil || len(profileResult.TargetPods) == 0 {
+		return
+	}
+
+	podName := profileResult.TargetPods[0].GetPod().NamespacedName.String()
+	s.holders.Set(systemPromptHolder{systemPrompt: systemPrompt, pod: podName}, struct{}{}, ttlcache.DefaultTTL)
+	log.FromContext(ctx).V(logutil.TRACE).Info("Recorded system prompt holder", "pod", podName)
+}
+
+// systemPromptKey returns the hash of the given request's target model and system prompt, and
+// whether the request has a system prompt.
+func (s *SystemPromptAffinity) systemPromptKey(request *types.LLMRequest) (uint64, bool) {
+	if request == nil {
+		return 0, false
+	}
+
+	var systemPrompt string
+	if s.delimiter != "" {
+		before, _, found := strings.Cut(request.Prompt, s.delimiter)
+		if !found || before == "" {
+			return 0, false
+		}
+		systemPrompt = before
+	} else {
+		if len(request.Prompt) <= s.length {
+			return 0, false
+		}
+		systemPrompt = request.Prompt[:s.length]
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(request.TargetModel))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(systemPrompt))
+	return h.Sum64(), true
+}
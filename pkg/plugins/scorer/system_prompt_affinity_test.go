@@ -0,0 +1,103 @@
+package scorer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestSystemPromptAffinity_Converge(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podC := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-c", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{podA, podB, podC}
+
+	ctx := context.Background()
+	s := scorer.NewSystemPromptAffinity(ctx, &scorer.SystemPromptAffinityParameters{Delimiter: "<|end_system|>"})
+
+	request := func(prompt string) *types.LLMRequest {
+		return &types.LLMRequest{TargetModel: "model", Prompt: prompt}
+	}
+	support1 := request("You are a support agent.<|end_system|>[2025-01-01] How do I reset my password?")
+	support2 := request("You are a support agent.<|end_system|>[2025-01-02] Where is my order?")
+	support3 := request("You are a support agent.<|end_system|>[2025-01-03] Cancel my subscription.")
+	coder := request("You are a coding assistant.<|end_system|>Write a sort function.")
+	noSystemPrompt := request("Tell me a joke.")
+
+	// unknown system prompt, all pods are scored equally
+	got := s.Score(ctx, nil, support1, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a new system prompt (-want +got): %v", diff)
+	}
+	s.PreRequest(ctx, support1, primaryResult(podA), 0)
+
+	// requests sharing the system prompt converge on the caching pod
+	got = s.Score(ctx, nil, support2, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 1, podB: 0, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a shared system prompt (-want +got): %v", diff)
+	}
+
+	// a request sent elsewhere adds another holder of the system prompt
+	s.PreRequest(ctx, support2, primaryResult(podB), 0)
+	got = s.Score(ctx, nil, support3, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 1, podB: 1, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a system prompt held by two pods (-want +got): %v", diff)
+	}
+
+	// requests with another system prompt are not affected
+	got = s.Score(ctx, nil, coder, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for another system prompt (-want +got): %v", diff)
+	}
+
+	// requests without a system prompt are not affected
+	s.PreRequest(ctx, noSystemPrompt, primaryResult(podC), 0)
+	got = s.Score(ctx, nil, noSystemPrompt, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0, podC: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for a request without a system prompt (-want +got): %v", diff)
+	}
+}
+
+func TestSystemPromptAffinity_Length(t *testing.T) {
+	podA := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-a", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	podB := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod-b", Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+	pods := []types.Pod{podA, podB}
+
+	ctx := context.Background()
+	s := scorer.NewSystemPromptAffinity(ctx, &scorer.SystemPromptAffinityParameters{Length: 10})
+
+	s.PreRequest(ctx, &types.LLMRequest{TargetModel: "model", Prompt: "0123456789 first question"}, primaryResult(podB), 0)
+
+	got := s.Score(ctx, nil, &types.LLMRequest{TargetModel: "model", Prompt: "0123456789 second question"}, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 1}, got); diff != "" {
+		t.Errorf("Unexpected output for a shared system prompt (-want +got): %v", diff)
+	}
+
+	// the same system prompt of another model is not held by the pod
+	got = s.Score(ctx, nil, &types.LLMRequest{TargetModel: "other", Prompt: "0123456789 second question"}, pods)
+	if diff := cmp.Diff(map[types.Pod]float64{podA: 0, podB: 0}, got); diff != "" {
+		t.Errorf("Unexpected output for another model (-want +got): %v", diff)
+	}
+}
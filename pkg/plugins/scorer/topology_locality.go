@@ -0,0 +1,115 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// TopologyLocalityType is the type of the TopologyLocality scorer.
+	TopologyLocalityType = "topology-locality-scorer"
+
+	// ZoneLabelDefault is the default pod label holding the zone of a pod
+	ZoneLabelDefault = "topology.kubernetes.io/zone"
+	// ZoneHeaderDefault is the default request header holding the zone of the requesting gateway
+	ZoneHeaderDefault = "x-gateway-zone"
+	// ZoneEnvVar is the environment variable holding the zone of the gateway, used for requests without the zone header
+	ZoneEnvVar = "GATEWAY_ZONE"
+)
+
+type topologyLocalityParameters struct {
+	// Label is the name of the pod label holding the zone of the pod.
+	Label string `json:"label"`
+	// ZoneHeader is the name of the request header holding the zone of the requesting gateway.
+	ZoneHeader string `json:"zoneHeader"`
+	// CrossZoneScore is the score, in range of 0-1, given to pods outside the zone of the gateway.
+	CrossZoneScore float64 `json:"crossZoneScore"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &TopologyLocality{}
+
+// TopologyLocalityFactory defines the factory function for the TopologyLocality scorer.
+func TopologyLocalityFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := topologyLocalityParameters{Label: ZoneLabelDefault, ZoneHeader: ZoneHeaderDefault}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", TopologyLocalityType, err)
+		}
+	}
+
+	scorer, err := NewTopologyLocality(parameters.Label, parameters.ZoneHeader, os.Getenv(ZoneEnvVar), parameters.CrossZoneScore)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", TopologyLocalityType, err)
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewTopologyLocality creates a new TopologyLocality scorer.
+// labelName - the name of the pod label holding the zone of a pod
+// zoneHeader - the name of the request header holding the zone of the requesting gateway
+// defaultZone - the zone of the gateway for requests without the zone header, empty if unknown
+// crossZoneScore - the score, in range of 0-1 (exclusive), given to pods outside the zone of the gateway
+func NewTopologyLocality(labelName string, zoneHeader string, defaultZone string, crossZoneScore float64) (*TopologyLocality, error) {
+	if crossZoneScore < 0 || crossZoneScore >= 1 {
+		return nil, errors.New("crossZoneScore must be in range of 0-1, excluding 1")
+	}
+
+	return &TopologyLocality{
+		typedName:      plugins.TypedName{Type: TopologyLocalityType},
+		labelName:      labelName,
+		zoneHeader:     zoneHeader,
+		defaultZone:    defaultZone,
+		crossZoneScore: crossZoneScore,
+	}, nil
+}
+
+// TopologyLocality is a scorer that prefers pods in the zone of the requesting gateway, since
+// routing across zones adds latency and egress cost. The gateway's zone is read from a request
+// header, falling back to the zone configured for the scheduler. Pods without a zone label are
+// considered to be in another zone.
+type TopologyLocality struct {
+	typedName      plugins.TypedName
+	labelName      string
+	zoneHeader     string
+	defaultZone    string
+	crossZoneScore float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *TopologyLocality) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *TopologyLocality) WithName(name string) *TopologyLocality {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores pods in the zone of the gateway with 1, and other pods with the cross zone score.
+// If the zone of the gateway is unknown, all pods are scored equally.
+func (s *TopologyLocality) Score(_ context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	zone := s.defaultZone
+	if request != nil && request.Headers[s.zoneHeader] != "" {
+		zone = request.Headers[s.zoneHeader]
+	}
+
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = 1.0
+		if zone != "" && pod.GetPod().Labels[s.labelName] != zone {
+			scoredPods[pod] = s.crossZoneScore
+		}
+	}
+	return scoredPods
+}
@@ -0,0 +1,84 @@
+package scorer_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
+)
+
+func TestTopologyLocality_Score(t *testing.T) {
+	zoneA := createPod("zone-a", "", map[string]string{scorer.ZoneLabelDefault: "us-east-1a"}, backendmetrics.MetricsState{})
+	zoneB := createPod("zone-b", "", map[string]string{scorer.ZoneLabelDefault: "us-east-1b"}, backendmetrics.MetricsState{})
+	unlabeled := createPod("unlabeled", "", nil, backendmetrics.MetricsState{})
+	pods := []types.Pod{zoneA, zoneB, unlabeled}
+
+	tests := []struct {
+		name        string
+		defaultZone string
+		req         *types.LLMRequest
+		wantScores  map[types.Pod]float64
+	}{
+		{
+			name:       "same zone pods are preferred",
+			req:        &types.LLMRequest{Headers: map[string]string{scorer.ZoneHeaderDefault: "us-east-1a"}},
+			wantScores: map[types.Pod]float64{zoneA: 1, zoneB: 0.2, unlabeled: 0.2},
+		},
+		{
+			name:        "configured zone is used without the header",
+			defaultZone: "us-east-1b",
+			req:         &types.LLMRequest{Headers: map[string]string{}},
+			wantScores:  map[types.Pod]float64{zoneA: 0.2, zoneB: 1, unlabeled: 0.2},
+		},
+		{
+			name:        "header overrides the configured zone",
+			defaultZone: "us-east-1b",
+			req:         &types.LLMRequest{Headers: map[string]string{scorer.ZoneHeaderDefault: "us-east-1a"}},
+			wantScores:  map[types.Pod]float64{zoneA: 1, zoneB: 0.2, unlabeled: 0.2},
+		},
+		{
+			name:       "no zone info scores all pods equally",
+			req:        &types.LLMRequest{Headers: map[string]string{}},
+			wantScores: map[types.Pod]float64{zoneA: 1, zoneB: 1, unlabeled: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := scorer.NewTopologyLocality(scorer.ZoneLabelDefault, scorer.ZoneHeaderDefault, test.defaultZone, 0.2)
+			require.NoError(t, err)
+
+			got := s.Score(context.Background(), nil, test.req, pods)
+			if diff := cmp.Diff(test.wantScores, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestTopologyLocalityFactory(t *testing.T) {
+	handle := plugins.NewEppHandle(context.Background())
+	pod := &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+
+	t.Setenv(scorer.ZoneEnvVar, "us-east-1a")
+	plugin, err := scorer.TopologyLocalityFactory("locality", nil, handle)
+	require.NoError(t, err)
+	got := plugin.(*scorer.TopologyLocality).Score(context.Background(), nil, &types.LLMRequest{}, []types.Pod{pod})
+	assert.Equal(t, map[types.Pod]float64{pod: 0}, got, "zone read from the environment")
+
+	_, err = scorer.TopologyLocalityFactory("locality", json.RawMessage(`{"crossZoneScore": 1}`), handle)
+	assert.Error(t, err)
+}
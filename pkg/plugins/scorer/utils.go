@@ -51,3 +51,27 @@ func getMinMax(scores map[string]int) (int, int) {
 
 	return minScore, maxScore
 }
+
+// blendScores returns, for each of the given pods, the weighted blend
+// weight*first + (1-weight)*second of the two given score maps.
+// Pods missing from a score map are considered to be scored with 0.
+func blendScores(pods []types.Pod, first map[types.Pod]float64, second map[types.Pod]float64,
+	weight float64) map[types.Pod]float64 {
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scoredPods[pod] = weight*first[pod] + (1-weight)*second[pod]
+	}
+
+	return scoredPods
+}
+
+// scoringPrompt returns the value of the given request header if it is set, e.g. a canonicalized
+// prompt or a routing key pre-computed by the gateway, and the request's prompt otherwise.
+func scoringPrompt(request *types.LLMRequest, header string) string {
+	if header != "" {
+		if value := request.Headers[header]; value != "" {
+			return value
+		}
+	}
+	return request.Prompt
+}
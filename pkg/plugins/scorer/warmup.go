@@ -0,0 +1,179 @@
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/params"
+)
+
+const (
+	// WarmupType is the type of the Warmup scorer.
+	WarmupType = "warmup-scorer"
+
+	defaultWarmupWindow       = 2 * time.Minute
+	defaultStartupGracePeriod = time.Minute
+
+	// warmupMaxPods defines the maximal number of remembered pods, the least recently scored
+	// pods are forgotten first
+	warmupMaxPods = 10000
+)
+
+// WarmupParameters defines the parameters for the Warmup scorer.
+type WarmupParameters struct {
+	// WarmupWindow defines the time over which the score of a new pod ramps up to the score of
+	// the pods that are already warm.
+	// This field accepts duration strings like "30s", "2m".
+	WarmupWindow string `json:"warmupWindow"`
+	// StartupGracePeriod defines the time, from the first scoring, during which pods seen for the
+	// first time are considered to be warm, as they already served requests before the scheduler started.
+	// This field accepts duration strings like "30s", "1m".
+	StartupGracePeriod string `json:"startupGracePeriod"`
+}
+
+// compile-time type assertion
+var _ framework.Scorer = &Warmup{}
+
+// WarmupFactory defines the factory function for the Warmup scorer.
+func WarmupFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := WarmupParameters{}
+	if rawParameters != nil {
+		if err := params.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", WarmupType, err)
+		}
+	}
+
+	return NewWarmup(handle.Context(), &parameters).WithName(name), nil
+}
+
+// NewWarmup creates a new Warmup scorer.
+func NewWarmup(ctx context.Context, params *WarmupParameters) *Warmup {
+	logger := log.FromContext(ctx)
+	warmupWindow := defaultWarmupWindow
+	startupGracePeriod := defaultStartupGracePeriod
+
+	if params != nil && params.WarmupWindow != "" {
+		paramsWarmupWindow, err := time.ParseDuration(params.WarmupWindow)
+		if err != nil || paramsWarmupWindow <= 0 {
+			logger.Error(err, "Invalid warmup window duration, using default warmup window")
+		} else {
+			warmupWindow = paramsWarmupWindow
+		}
+	}
+	if params != nil && params.StartupGracePeriod != "" {
+		paramsStartupGracePeriod, err := time.ParseDuration(params.StartupGracePeriod)
+		if err != nil || paramsStartupGracePeriod < 0 {
+			logger.Error(err, "Invalid startup grace period duration, using default startup grace period")
+		} else {
+			startupGracePeriod = paramsStartupGracePeriod
+		}
+	}
+
+	return &Warmup{
+		typedName:          plugins.TypedName{Type: WarmupType},
+		warmupWindow:       warmupWindow,
+		startupGracePeriod: startupGracePeriod,
+		firstSeen:          make(map[string]podSighting),
+	}
+}
+
+// podSighting holds when a pod was first and last scored.
+type podSighting struct {
+	first time.Time
+	last  time.Time
+}
+
+// Warmup is a scorer that de-prioritizes pods that recently joined the pool, e.g. during a
+// rolling update. Such pods have cold caches and empty queues, so load and cache based scorers
+// tend to over-prefer them before they are warmed up. The score of a new pod ramps up linearly
+// from 0 to 1 over the warmup window, and pods that are already warm are scored with 1.
+//
+// Since pods carry no start time, the age of a pod is measured from the first time it is scored.
+// Pods first scored within the startup grace period are considered to be warm, as they already
+// served requests before the scheduler started.
+type Warmup struct {
+	typedName          plugins.TypedName
+	warmupWindow       time.Duration
+	startupGracePeriod time.Duration
+
+	// firstSeen holds when each pod was first and last scored, keyed by pod name and address,
+	// so a restarted pod with a new address is considered new
+	firstSeen map[string]podSighting
+	// started is when the scorer first scored pods, nil until then
+	started *time.Time
+	mutex   sync.Mutex
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Warmup) TypedName() plugins.TypedName {
+	return s.typedName
+}
+
+// WithName sets the name of the plugin.
+func (s *Warmup) WithName(name string) *Warmup {
+	s.typedName.Name = name
+	return s
+}
+
+// Score scores the given pods by how far they are into their warmup window, in range of 0-1.
+func (s *Warmup) Score(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scoredPods := s.scoreAt(pods, time.Now())
+
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Scored pods by warmup", "scores", scoredPods)
+	return scoredPods
+}
+
+// scoreAt scores the given pods by how far they are into their warmup window at the given time.
+func (s *Warmup) scoreAt(pods []types.Pod, now time.Time) map[types.Pod]float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started == nil {
+		s.started = &now
+	}
+	// pods seen during the startup grace period already served requests before the scheduler started
+	inGracePeriod := now.Sub(*s.started) <= s.startupGracePeriod
+
+	scoredPods := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		key := pod.GetPod().NamespacedName.String() + "/" + pod.GetPod().Address
+		sighting, found := s.firstSeen[key]
+		if !found {
+			sighting.first = now
+			if inGracePeriod {
+				sighting.first = now.Add(-s.warmupWindow)
+			}
+		}
+		sighting.last = now
+		s.firstSeen[key] = sighting
+
+		scoredPods[pod] = min(float64(now.Sub(sighting.first))/float64(s.warmupWindow), 1.0)
+	}
+
+	for len(s.firstSeen) > warmupMaxPods {
+		s.forgetLeastRecentLocked()
+	}
+
+	return scoredPods
+}
+
+// forgetLeastRecentLocked forgets the least recently scored pod. Must be called with the mutex held.
+func (s *Warmup) forgetLeastRecentLocked() {
+	leastRecentKey := ""
+	var leastRecent time.Time
+	for key, sighting := range s.firstSeen {
+		if leastRecentKey == "" || sighting.last.Before(leastRecent) {
+			leastRecentKey, leastRecent = key, sighting.last
+		}
+	}
+	delete(s.firstSeen, leastRecentKey)
+}
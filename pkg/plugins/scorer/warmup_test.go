@@ -0,0 +1,50 @@
+package scorer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestWarmupScorer(t *testing.T) {
+	oldPod := createPod("old", "10.0.0.1", nil, backendmetrics.MetricsState{})
+	filteredPod := createPod("filtered", "10.0.0.4", nil, backendmetrics.MetricsState{})
+	newerPod := createPod("new", "10.0.0.2", nil, backendmetrics.MetricsState{})
+	restartedPod := createPod("new", "10.0.0.3", nil, backendmetrics.MetricsState{})
+
+	s := NewWarmup(context.Background(), &WarmupParameters{WarmupWindow: "2m", StartupGracePeriod: "1m"})
+	start := time.Now()
+
+	// pods seen during the startup grace period are warm, even if filtered out of the first requests
+	assert.Equal(t, map[types.Pod]float64{oldPod: 1}, s.scoreAt([]types.Pod{oldPod}, start))
+	assert.Equal(t, map[types.Pod]float64{filteredPod: 1}, s.scoreAt([]types.Pod{filteredPod}, start.Add(30*time.Second)))
+
+	tests := []struct {
+		name       string
+		elapsed    time.Duration
+		pods       []types.Pod
+		wantScores map[types.Pod]float64
+	}{
+		{name: "new pod is suppressed", elapsed: 2 * time.Minute, pods: []types.Pod{oldPod, newerPod},
+			wantScores: map[types.Pod]float64{oldPod: 1, newerPod: 0}},
+		{name: "new pod ramps up", elapsed: 3 * time.Minute, pods: []types.Pod{oldPod, newerPod},
+			wantScores: map[types.Pod]float64{oldPod: 1, newerPod: 0.5}},
+		{name: "new pod is warm after the warmup window", elapsed: 4 * time.Minute, pods: []types.Pod{oldPod, newerPod},
+			wantScores: map[types.Pod]float64{oldPod: 1, newerPod: 1}},
+		{name: "restarted pod is new again", elapsed: 5 * time.Minute, pods: []types.Pod{oldPod, newerPod, restartedPod},
+			wantScores: map[types.Pod]float64{oldPod: 1, newerPod: 1, restartedPod: 0}},
+		{name: "pod not scored for long is still warm", elapsed: 5 * time.Hour, pods: []types.Pod{filteredPod},
+			wantScores: map[types.Pod]float64{filteredPod: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.scoreAt(test.pods, start.Add(test.elapsed))
+			assert.Equal(t, test.wantScores, got)
+		})
+	}
+}
@@ -2,9 +2,13 @@ package pd_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/go-logr/logr/testr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -12,8 +16,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics" // Import config for thresholds
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
@@ -22,6 +28,7 @@ import (
 
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/filter"
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/profile"
+	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/rejection"
 	"github.com/llm-d/llm-d-inference-scheduler/pkg/plugins/scorer"
 )
 
@@ -253,3 +260,751 @@ func TestPDSchedule(t *testing.T) {
 		})
 	}
 }
+
+// Tests the scheduler behavior when the PD decision is forced by a request header.
+func TestPDScheduleForcedProfile(t *testing.T) {
+	prefillPod := createPod("pod1", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("pod2", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	prefillDecodeResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			decode:  {TargetPods: []types.Pod{&types.ScoredPod{Pod: decodePod}}},
+			prefill: {TargetPods: []types.Pod{&types.ScoredPod{Pod: prefillPod}}},
+		},
+		PrimaryProfileName: decode,
+	}
+	decodeResult := &types.SchedulingResult{
+		ProfileResults: map[string]*types.ProfileRunResult{
+			decode: {TargetPods: []types.Pod{&types.ScoredPod{Pod: decodePod}}},
+		},
+		PrimaryProfileName: decode,
+	}
+
+	tests := []struct {
+		name         string
+		allowForcing bool
+		prompt       string
+		forced       string
+		wantRes      *types.SchedulingResult
+	}{
+		{
+			name:         "forced decode only overrides a long prompt",
+			allowForcing: true,
+			prompt:       "12345678901",
+			forced:       profile.ForceProfileDecode,
+			wantRes:      decodeResult,
+		},
+		{
+			name:         "forced prefill overrides a short prompt",
+			allowForcing: true,
+			prompt:       "12345",
+			forced:       profile.ForceProfilePrefill,
+			wantRes:      prefillDecodeResult,
+		},
+		{
+			name:         "unknown forced value falls back to the threshold",
+			allowForcing: true,
+			prompt:       "12345678901",
+			forced:       "other",
+			wantRes:      prefillDecodeResult,
+		},
+		{
+			name:         "forcing is ignored when not allowed",
+			allowForcing: false,
+			prompt:       "12345",
+			forced:       profile.ForceProfilePrefill,
+			wantRes:      decodeResult,
+		},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5)
+			if test.allowForcing {
+				profileHandle = profileHandle.WithForceProfileHeader(profile.ForceProfileHeaderDefault)
+			}
+			scheduler := newPDScheduler(t, profileHandle)
+
+			req := &types.LLMRequest{
+				RequestId:   uuid.NewString(),
+				TargetModel: "critical",
+				Prompt:      test.prompt,
+				Headers:     map[string]string{profile.ForceProfileHeaderDefault: test.forced},
+			}
+			got, err := scheduler.Schedule(ctx, req, []types.Pod{prefillPod, decodePod})
+			assert.NoError(t, err)
+
+			if diff := cmp.Diff(test.wantRes, got, cmpopts.IgnoreFields(types.ScoredPod{}, "Score")); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+// Tests the PD decision for identical prompts when the threshold is measured in bytes or in tokens.
+func TestPDScheduleThresholdUnit(t *testing.T) {
+	prefillPod := createPod("pod1", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("pod2", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	// 24 bytes, approximately 6 tokens
+	shortInTokens := "what is the capital city"
+	// 59 bytes, approximately 15 tokens
+	longInTokens := "summarize the following paragraph about european capitals"
+
+	tests := []struct {
+		name        string
+		unit        string
+		prompt      string
+		wantPrefill bool
+	}{
+		{name: "bytes unit, short in tokens", unit: profile.ThresholdUnitBytes, prompt: shortInTokens, wantPrefill: true},
+		{name: "tokens unit, short in tokens", unit: profile.ThresholdUnitTokens, prompt: shortInTokens, wantPrefill: false},
+		{name: "bytes unit, long in tokens", unit: profile.ThresholdUnitBytes, prompt: longInTokens, wantPrefill: true},
+		{name: "tokens unit, long in tokens", unit: profile.ThresholdUnitTokens, prompt: longInTokens, wantPrefill: true},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profileHandle, err := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).
+				WithThresholdUnit(test.unit)
+			assert.NoError(t, err)
+			scheduler := newPDScheduler(t, profileHandle)
+
+			req := &types.LLMRequest{
+				RequestId:   uuid.NewString(),
+				TargetModel: "critical",
+				Prompt:      test.prompt,
+			}
+			got, err := scheduler.Schedule(ctx, req, []types.Pod{prefillPod, decodePod})
+			assert.NoError(t, err)
+
+			_, gotPrefill := got.ProfileResults[prefill]
+			assert.Equal(t, test.wantPrefill, gotPrefill)
+		})
+	}
+}
+
+func TestPDProfileHandlerInvalidThresholdUnit(t *testing.T) {
+	_, err := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).WithThresholdUnit("words")
+	assert.Error(t, err)
+}
+
+// Tests that the profile handler fails to load when its threshold relies on a nonexistent prefix plugin,
+// or when its parameters are misspelled.
+func TestPDProfileHandlerFactoryPrefixPluginName(t *testing.T) {
+	ctx := context.Background()
+	handle := plugins.NewEppHandle(ctx)
+	handle.AddPlugin("prefix", prefix.New(ctx, prefix.DefaultConfig))
+	handle.AddPlugin("picker", picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    string
+	}{
+		{name: "existing prefix plugin", parameters: `{"threshold": 10, "prefixPluginName": "prefix"}`},
+		{name: "nonexistent prefix plugin", parameters: `{"threshold": 10, "prefixPluginName": "missing"}`, wantErr: "prefixPluginName"},
+		{name: "plugin which is not a prefix plugin", parameters: `{"threshold": 10, "prefixPluginName": "picker"}`, wantErr: "prefixPluginName"},
+		{name: "nonexistent prefix plugin without threshold", parameters: `{"threshold": 0, "prefixPluginName": "missing"}`},
+		{name: "misspelled parameter", parameters: `{"treshold": 10, "prefixPluginName": "missing"}`, wantErr: `unknown field "treshold"`},
+		{name: "class threshold with nonexistent prefix plugin", parameters: `{"classThresholds": {"best-effort": 100}, "prefixPluginName": "missing"}`,
+			wantErr: "prefixPluginName"},
+		{name: "zero class threshold with nonexistent prefix plugin", parameters: `{"classThresholds": {"premium": 0}, "prefixPluginName": "missing"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := profile.PdProfileHandlerFactory("pd", json.RawMessage(test.parameters), handle)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// Tests the PD decision when prefill is bypassed for lightly loaded decode pods.
+func TestPDScheduleDecodeLoadBypass(t *testing.T) {
+	prefillPod := createPod("pod1", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+
+	tests := []struct {
+		name        string
+		prompt      string
+		decodeQueue int
+		wantPrefill bool
+	}{
+		{name: "short prompt, idle decode pod", prompt: "12345", decodeQueue: 0, wantPrefill: false},
+		{name: "short prompt, loaded decode pod", prompt: "12345", decodeQueue: 5, wantPrefill: false},
+		{name: "long prompt, idle decode pod", prompt: "12345678901", decodeQueue: 0, wantPrefill: false},
+		{name: "long prompt, loaded decode pod", prompt: "12345678901", decodeQueue: 5, wantPrefill: true},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).
+				WithDecodeLoadBypassThreshold(2)
+			scheduler := newPDScheduler(t, profileHandle)
+
+			req := &types.LLMRequest{
+				RequestId:   uuid.NewString(),
+				TargetModel: "critical",
+				Prompt:      test.prompt,
+			}
+			got, err := scheduler.Schedule(ctx, req, []types.Pod{prefillPod, createPod("pod2", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, test.decodeQueue)})
+			assert.NoError(t, err)
+
+			_, gotPrefill := got.ProfileResults[prefill]
+			assert.Equal(t, test.wantPrefill, gotPrefill)
+		})
+	}
+}
+
+// Tests that pods serving both roles are candidates of both the prefill and the decode profiles.
+func TestPDScheduleRoleBoth(t *testing.T) {
+	bothPod1 := createPod("both1", "1.1.1.1", map[string]string{filter.RoleLabel: filter.RoleBoth}, 0)
+	bothPod2 := createPod("both2", "2.2.2.2", map[string]string{filter.RoleLabel: filter.RoleBoth}, 3)
+	prefillPod := createPod("prefill", "3.3.3.3", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("decode", "4.4.4.4", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	tests := []struct {
+		name        string
+		input       []types.Pod
+		wantDecode  types.Pod
+		wantPrefill []types.Pod // the pods any of which may be picked for prefill
+	}{
+		{
+			name:        "only both pods",
+			input:       []types.Pod{bothPod1, bothPod2},
+			wantDecode:  bothPod1,
+			wantPrefill: []types.Pod{bothPod1, bothPod2},
+		},
+		{
+			name:        "mixed pool",
+			input:       []types.Pod{prefillPod, decodePod, bothPod2},
+			wantDecode:  decodePod,
+			wantPrefill: []types.Pod{prefillPod, bothPod2},
+		},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5)
+			scheduler := newPDScheduler(t, profileHandle, framework.NewWeightedScorer(scorer.NewLoadAware(ctx, scorer.QueueThresholdDefault), 1))
+
+			req := &types.LLMRequest{
+				RequestId:   uuid.NewString(),
+				TargetModel: "critical",
+				Prompt:      "12345678901",
+			}
+			got, err := scheduler.Schedule(ctx, req, test.input)
+			assert.NoError(t, err)
+
+			decodeResult := got.ProfileResults[decode]
+			if assert.NotNil(t, decodeResult) && assert.Len(t, decodeResult.TargetPods, 1) {
+				assert.Equal(t, test.wantDecode, decodeResult.TargetPods[0].(*types.ScoredPod).Pod)
+			}
+			prefillResult := got.ProfileResults[prefill]
+			if assert.NotNil(t, prefillResult) && assert.Len(t, prefillResult.TargetPods, 1) {
+				assert.Contains(t, test.wantPrefill, prefillResult.TargetPods[0].(*types.ScoredPod).Pod)
+			}
+		})
+	}
+}
+
+// Tests that the reason of a request rejected by the max prompt length filter is surfaced.
+func TestPDScheduleMaxPromptLength(t *testing.T) {
+	prefillPod := createPod("pod1", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("pod2", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	maxPromptLength, err := filter.NewMaxPromptLength("max-prompt-length", 20, filter.PromptLengthUnitBytes, 0)
+	assert.NoError(t, err)
+
+	prefillSchedulerProfile := framework.NewSchedulerProfile().
+		WithFilters(filter.NewPrefillRole()).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	decodeSchedulerProfile := framework.NewSchedulerProfile().
+		WithFilters(maxPromptLength, filter.NewDecodeRole()).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 0, 5)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: prefillSchedulerProfile,
+		decode:  decodeSchedulerProfile,
+	}))
+
+	got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "short prompt"},
+		[]types.Pod{prefillPod, decodePod})
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+
+	_, err = scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "a prompt longer than the limit"},
+		[]types.Pod{prefillPod, decodePod})
+	var reason *rejection.PromptTooLongError
+	assert.ErrorAs(t, err, &reason)
+	assert.Equal(t, 30, reason.Length)
+}
+
+// Tests that a score floor on an affinity scorer lets a load scorer spread traffic away from an
+// overloaded preferred pod, while a preferred pod that isn't overloaded still wins.
+func TestPDScheduleScoreFloor(t *testing.T) {
+	tests := []struct {
+		name        string
+		floor       float64
+		stickyQueue int
+		wantPod     string
+	}{
+		{name: "no floor, overloaded preferred pod", floor: 0, stickyQueue: 9, wantPod: "sticky"},
+		{name: "floor, overloaded preferred pod", floor: 0.6, stickyQueue: 9, wantPod: "fresh"},
+		{name: "floor, idle preferred pod", floor: 0.6, stickyQueue: 0, wantPod: "sticky"},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			affinityScorer, err := scorer.NewScoreFloor(scorer.NewSessionAffinity(), test.floor)
+			assert.NoError(t, err)
+
+			decodeSchedulerProfile := framework.NewSchedulerProfile().
+				WithFilters(filter.NewDecodeRole()).
+				WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+			err = decodeSchedulerProfile.AddPlugins(
+				framework.NewWeightedScorer(affinityScorer, 1),
+				framework.NewWeightedScorer(scorer.NewLoadAware(ctx, 10), 1))
+			assert.NoError(t, err, "SchedulerProfile AddPlugins returned unexpected error")
+
+			profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5)
+			scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+				prefill: framework.NewSchedulerProfile().WithFilters(filter.NewPrefillRole()),
+				decode:  decodeSchedulerProfile,
+			}))
+
+			req := &types.LLMRequest{
+				RequestId: uuid.NewString(),
+				Prompt:    "12345",
+				Headers:   map[string]string{"x-session-token": base64.StdEncoding.EncodeToString([]byte("default/sticky"))},
+			}
+			got, err := scheduler.Schedule(ctx, req, []types.Pod{
+				createPod("sticky", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, test.stickyQueue),
+				createPod("fresh", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0),
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.wantPod, got.ProfileResults[decode].TargetPods[0].GetPod().NamespacedName.Name)
+		})
+	}
+}
+
+// Tests that requests spill to the fallback decode profile when the decode profile finds no available decode workers.
+func TestPDScheduleFallbackDecode(t *testing.T) {
+	const (
+		fallbackDecode = "overflow-decode"
+		poolLabel      = "pool"
+	)
+	primaryPod := createPod("primary", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode, poolLabel: "primary"}, 0)
+	overflowPod := createPod("overflow", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode, poolLabel: "overflow"}, 0)
+	prefillPod := createPod("prefill", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+
+	tests := []struct {
+		name        string
+		pods        []types.Pod
+		wantProfile string
+		wantPod     types.Pod
+		wantErr     bool
+	}{
+		{name: "primary decode succeeds", pods: []types.Pod{primaryPod, overflowPod}, wantProfile: decode, wantPod: primaryPod},
+		{name: "primary decode empty, fallback succeeds", pods: []types.Pod{overflowPod}, wantProfile: fallbackDecode, wantPod: overflowPod},
+		{name: "both decode profiles empty", pods: []types.Pod{prefillPod}, wantErr: true},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	newDecodeProfile := func(pool string) *framework.SchedulerProfile {
+		return framework.NewSchedulerProfile().
+			WithFilters(filter.NewDecodeRole(), filter.NewByLabel(pool, poolLabel, false, pool)).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	}
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 0, 5).
+		WithFallbackDecodeProfile(fallbackDecode)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill:        framework.NewSchedulerProfile().WithFilters(filter.NewPrefillRole()),
+		decode:         newDecodeProfile("primary"),
+		fallbackDecode: newDecodeProfile("overflow"),
+	}))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "12345"}, test.pods)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.wantProfile, got.PrimaryProfileName)
+			assert.Len(t, got.ProfileResults, 1)
+			assert.Equal(t, test.wantPod, got.ProfileResults[test.wantProfile].TargetPods[0].(*types.ScoredPod).Pod)
+		})
+	}
+}
+
+// Tests that a request rejected by the max prompt length filter is not scheduled by the fallback decode profile.
+func TestPDScheduleFallbackDecodeMaxPromptLength(t *testing.T) {
+	const fallbackDecode = "overflow-decode"
+	decodePod := createPod("decode", "5.6.7.8", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	maxPromptLength, err := filter.NewMaxPromptLength("max-prompt-length", 20, filter.PromptLengthUnitBytes, 0)
+	assert.NoError(t, err)
+
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 0, 5).
+		WithFallbackDecodeProfile(fallbackDecode)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: framework.NewSchedulerProfile().WithFilters(filter.NewPrefillRole()),
+		decode: framework.NewSchedulerProfile().
+			WithFilters(maxPromptLength, filter.NewDecodeRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+		fallbackDecode: framework.NewSchedulerProfile().
+			WithFilters(filter.NewDecodeRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+	}))
+
+	got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "short prompt"},
+		[]types.Pod{decodePod})
+	assert.NoError(t, err)
+	assert.Equal(t, decode, got.PrimaryProfileName)
+
+	got, err = scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "a prompt longer than the limit"},
+		[]types.Pod{decodePod})
+	assert.Nil(t, got)
+	var reason *rejection.PromptTooLongError
+	assert.ErrorAs(t, err, &reason)
+}
+
+// Tests that running prefill first lets the decode profile prefer decode pods co-located with the prefill pod.
+func TestPDSchedulePrefillFirst(t *testing.T) {
+	prefillPod := createPod("prefill", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RolePrefill, scorer.NodeLabelDefault: "node-2"}, 0)
+	decodePods := []types.Pod{
+		createPod("decode-1", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RoleDecode, scorer.NodeLabelDefault: "node-1"}, 0),
+		createPod("decode-2", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RoleDecode, scorer.NodeLabelDefault: "node-2"}, 0),
+		createPod("decode-3", "1.2.3.4", map[string]string{filter.RoleLabel: filter.RoleDecode, scorer.NodeLabelDefault: "node-3"}, 0),
+	}
+
+	tests := []struct {
+		name        string
+		prompt      string
+		pods        []types.Pod
+		wantPrefill bool
+		wantDecode  string
+	}{
+		{name: "decode co-located with prefill", prompt: "12345678901", pods: append([]types.Pod{prefillPod}, decodePods...),
+			wantPrefill: true, wantDecode: "decode-2"},
+		{name: "no co-located decode pod", prompt: "12345678901", pods: []types.Pod{prefillPod, decodePods[0]},
+			wantPrefill: true, wantDecode: "decode-1"},
+		{name: "short prompt skips prefill", prompt: "12345", pods: append([]types.Pod{prefillPod}, decodePods...),
+			wantPrefill: false},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	locality, err := scorer.NewPrefillLocality([]string{scorer.NodeLabelDefault})
+	assert.NoError(t, err)
+	decodeSchedulerProfile := framework.NewSchedulerProfile().
+		WithFilters(filter.NewDecodeRole()).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	err = decodeSchedulerProfile.AddPlugins(framework.NewWeightedScorer(locality, 1))
+	assert.NoError(t, err, "SchedulerProfile AddPlugins returned unexpected error")
+
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).WithPrefillFirst(true)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: framework.NewSchedulerProfile().
+			WithFilters(filter.NewPrefillRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+		decode: decodeSchedulerProfile,
+	}))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: test.prompt}, test.pods)
+			assert.NoError(t, err)
+
+			_, gotPrefill := got.ProfileResults[prefill]
+			assert.Equal(t, test.wantPrefill, gotPrefill)
+			assert.Equal(t, decode, got.PrimaryProfileName)
+			if test.wantDecode != "" {
+				assert.Equal(t, test.wantDecode, got.ProfileResults[decode].TargetPods[0].GetPod().NamespacedName.Name)
+			}
+		})
+	}
+}
+
+// Tests that the PD scheduling decision of each request is logged as a single structured record.
+func TestPDScheduleDecisionLog(t *testing.T) {
+	prefillPod := createPod("prefill", "", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("decode", "", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	tests := []struct {
+		name       string
+		prompt     string
+		wantFields []string
+	}{
+		{name: "prefill", prompt: "12345678901", wantFields: []string{
+			`"decode"="default/decode"`, `"prefill"="default/prefill"`, `"decode"=0.5`, `"prefill"=0`,
+			`"scorerScores"={"decode"={"load-aware-scorer"=0.5}}`, `"prefill"=true`, `"pdDecision"="prefill"`, `"threshold"=10`}},
+		{name: "decode only", prompt: "12345", wantFields: []string{
+			`"pods"={"decode"="default/decode"}`, `"scores"={"decode"=0.5}`,
+			`"scorerScores"={"decode"={"load-aware-scorer"=0.5}}`,
+			`"prefill"=false`, `"pdDecision"="below-threshold"`, `"threshold"=10`}},
+	}
+
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).
+		WithDecisionLogVerbosity(4).WithPrefillFirst(true)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: framework.NewSchedulerProfile().
+			WithFilters(filter.NewPrefillRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+		decode: framework.NewSchedulerProfile().
+			WithFilters(filter.NewDecodeRole()).
+			WithScorers(framework.NewWeightedScorer(scorer.NewTraced(scorer.NewLoadAware(context.Background(), scorer.QueueThresholdDefault),
+				scorer.NewScoringTrace()), 1)).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+	}))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var records []string
+			logger := funcr.New(func(prefix, args string) {
+				if strings.Contains(args, `"msg"="Scheduling decision"`) {
+					records = append(records, args)
+				}
+			}, funcr.Options{Verbosity: 4})
+			requestID := uuid.NewString()
+
+			_, err := scheduler.Schedule(log.IntoContext(context.Background(), logger),
+				&types.LLMRequest{RequestId: requestID, Prompt: test.prompt}, []types.Pod{prefillPod, decodePod})
+			assert.NoError(t, err)
+
+			assert.Len(t, records, 1)
+			for _, field := range append(test.wantFields, `"requestId"="`+requestID+`"`, `"primaryProfile"="decode"`) {
+				assert.Contains(t, records[0], field)
+			}
+		})
+	}
+
+	t.Run("disabled below its verbosity", func(t *testing.T) {
+		var records []string
+		logger := funcr.New(func(prefix, args string) {
+			if strings.Contains(args, `"msg"="Scheduling decision"`) {
+				records = append(records, args)
+			}
+		}, funcr.Options{Verbosity: 3})
+
+		_, err := scheduler.Schedule(log.IntoContext(context.Background(), logger),
+			&types.LLMRequest{RequestId: uuid.NewString(), Prompt: "12345"}, []types.Pod{prefillPod, decodePod})
+		assert.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
+// Tests that each profile returns its top-K pods, ordered by score, when its picker returns multiple endpoints.
+func TestPDScheduleTopK(t *testing.T) {
+	pods := []types.Pod{
+		createPod("prefill-idle", "prefill-idle", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0),
+		createPod("prefill-busy", "prefill-busy", map[string]string{filter.RoleLabel: filter.RolePrefill}, 6),
+		createPod("prefill-loaded", "prefill-loaded", map[string]string{filter.RoleLabel: filter.RolePrefill}, 3),
+		createPod("decode-busy", "decode-busy", map[string]string{filter.RoleLabel: filter.RoleDecode}, 8),
+		createPod("decode-idle", "decode-idle", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0),
+		createPod("decode-loaded", "decode-loaded", map[string]string{filter.RoleLabel: filter.RoleDecode}, 2),
+		createPod("decode-overloaded", "decode-overloaded", map[string]string{filter.RoleLabel: filter.RoleDecode}, 9),
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	newProfile := func(roleFilter framework.Filter, maxEndpoints int) *framework.SchedulerProfile {
+		schedulerProfile := framework.NewSchedulerProfile().
+			WithFilters(roleFilter).
+			WithPicker(picker.NewMaxScorePicker(maxEndpoints))
+		err := schedulerProfile.AddPlugins(framework.NewWeightedScorer(scorer.NewLoadAware(ctx, 10), 1))
+		assert.NoError(t, err, "SchedulerProfile AddPlugins returned unexpected error")
+		return schedulerProfile
+	}
+
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 0, 5)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: newProfile(filter.NewPrefillRole(), 2),
+		decode:  newProfile(filter.NewDecodeRole(), 3),
+	}))
+
+	got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: "12345"}, pods)
+	assert.NoError(t, err)
+
+	podNames := func(profileName string) []string {
+		names := []string{}
+		for _, pod := range got.ProfileResults[profileName].TargetPods {
+			names = append(names, pod.GetPod().NamespacedName.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"prefill-idle", "prefill-loaded"}, podNames(prefill))
+	assert.Equal(t, []string{"decode-idle", "decode-loaded", "decode-busy"}, podNames(decode))
+}
+
+// Tests that the PD decisions and the compared non-cached suffix lengths are reported as metrics.
+func TestPDScheduleDecisionMetrics(t *testing.T) {
+	prefillPod := createPod("prefill", "", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("decode", "", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	const handlerName = "pd-decision-metrics"
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).WithName(handlerName)
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: framework.NewSchedulerProfile().
+			WithFilters(filter.NewPrefillRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+		decode: framework.NewSchedulerProfile().
+			WithFilters(filter.NewDecodeRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+	}))
+
+	decisions := func(decision string) float64 {
+		return gatherMetricValue(t, "inference_extension_pd_profile_handler_decisions_total",
+			map[string]string{"plugin_name": handlerName, "decision": decision})
+	}
+	suffixLengths := func() float64 {
+		return gatherMetricValue(t, "inference_extension_pd_profile_handler_non_cached_suffix_length",
+			map[string]string{"plugin_name": handlerName})
+	}
+
+	for _, prompt := range []string{"12345", "123", "12345678901"} {
+		_, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: prompt}, []types.Pod{prefillPod, decodePod})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2.0, decisions("decode_only"))
+	assert.Equal(t, 1.0, decisions("prefill"))
+	assert.Equal(t, 3.0, suffixLengths())
+}
+
+// Tests that requests of different classes are compared against the thresholds of their classes.
+func TestPDScheduleClassThresholds(t *testing.T) {
+	prefillPod := createPod("prefill", "", map[string]string{filter.RoleLabel: filter.RolePrefill}, 0)
+	decodePod := createPod("decode", "", map[string]string{filter.RoleLabel: filter.RoleDecode}, 0)
+
+	// 20 bytes
+	prompt := "12345678901234567890"
+
+	tests := []struct {
+		name        string
+		class       string
+		wantPrefill bool
+	}{
+		{name: "premium class always prefills", class: "premium", wantPrefill: true},
+		{name: "best-effort class prefills only very long prompts", class: "best-effort", wantPrefill: false},
+		{name: "unknown class uses the default threshold", class: "unknown", wantPrefill: true},
+		{name: "no class uses the default threshold", wantPrefill: true},
+	}
+
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	profileHandle := profile.NewPdProfileHandler(prefill, decode, prefix.PrefixCachePluginType, 10, 5).
+		WithClassThresholds(profile.ClassHeaderDefault, map[string]int{"premium": 0, "best-effort": 100})
+	scheduler := scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandle, map[string]*framework.SchedulerProfile{
+		prefill: framework.NewSchedulerProfile().
+			WithFilters(filter.NewPrefillRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+		decode: framework.NewSchedulerProfile().
+			WithFilters(filter.NewDecodeRole()).
+			WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints)),
+	}))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if test.class != "" {
+				headers[profile.ClassHeaderDefault] = test.class
+			}
+			got, err := scheduler.Schedule(ctx, &types.LLMRequest{RequestId: uuid.NewString(), Prompt: prompt, Headers: headers},
+				[]types.Pod{prefillPod, decodePod})
+			assert.NoError(t, err)
+
+			_, gotPrefill := got.ProfileResults[prefill]
+			assert.Equal(t, test.wantPrefill, gotPrefill)
+		})
+	}
+}
+
+// newPDScheduler creates a scheduler with the given profile handler, whose prefill profile is scored by
+// a new prefix cache scorer, and whose decode profile is scored by the given scorers and tracks the
+// prefix cache scorer without weight.
+func newPDScheduler(t *testing.T, profileHandler framework.ProfileHandler, decodeScorers ...*framework.WeightedScorer) *scheduling.Scheduler {
+	prefixScorer := prefix.New(context.Background(), prefix.Config{HashBlockSize: 5, MaxPrefixBlocksToMatch: 256, LRUCapacityPerServer: 31250})
+
+	prefillSchedulerProfile := framework.NewSchedulerProfile().
+		WithFilters(filter.NewPrefillRole()).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	err := prefillSchedulerProfile.AddPlugins(framework.NewWeightedScorer(prefixScorer, 50))
+	assert.NoError(t, err, "SchedulerProfile AddPlugins returned unexpected error")
+
+	decodeSchedulerProfile := framework.NewSchedulerProfile().
+		WithFilters(filter.NewDecodeRole()).
+		WithScorers(decodeScorers...).
+		WithPicker(picker.NewMaxScorePicker(picker.DefaultMaxNumOfEndpoints))
+	err = decodeSchedulerProfile.AddPlugins(framework.NewWeightedScorer(prefixScorer, 0))
+	assert.NoError(t, err, "SchedulerProfile AddPlugins returned unexpected error")
+
+	return scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profileHandler, map[string]*framework.SchedulerProfile{
+		prefill: prefillSchedulerProfile,
+		decode:  decodeSchedulerProfile,
+	}))
+}
+
+// createPod creates a pod in the default namespace with the given labels and waiting queue size.
+func createPod(name string, address string, labels map[string]string, waitingQueueSize int) *types.PodMetrics {
+	return &types.PodMetrics{
+		Pod: &backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+			Address:        address,
+			Labels:         labels,
+		},
+		MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: waitingQueueSize},
+	}
+}
+
+// gatherMetricValue scrapes the metrics registry and returns the value of the counter, or the sample
+// count of the histogram, with the given name and labels, or -1 if it is not found.
+func gatherMetricValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			metricLabels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+			if !cmp.Equal(labels, metricLabels) {
+				continue
+			}
+			if metric.GetHistogram() != nil {
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return -1
+}